@@ -2,11 +2,15 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/joho/godotenv"
@@ -14,6 +18,11 @@ import (
 
 	"github.com/MrZloHex/monolink"
 	"monoview/internal/app"
+	"monoview/internal/config"
+	"monoview/internal/creds"
+	"monoview/internal/logfile"
+	"monoview/internal/notify"
+	"monoview/internal/types"
 )
 
 const (
@@ -25,10 +34,45 @@ func main() {
 
 	defaultURLVal := envOr("MONOVIEW_URL", "wss://127.0.0.1:8443")
 	defaultLogPath := envOr("MONOVIEW_LOG", "monoview.log")
+	defaultLogLevel := envOr("MONOVIEW_LOG_LEVEL", "info")
+	defaultLogFormat := envOr("MONOVIEW_LOG_FORMAT", "text")
+	defaultLogMaxSize := envOr("MONOVIEW_LOG_MAX_SIZE", "10MB")
+	defaultLogKeep, err := strconv.Atoi(envOr("MONOVIEW_LOG_KEEP", "3"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "MONOVIEW_LOG_KEEP: %v\n", err)
+		os.Exit(1)
+	}
 	defaultTLSCert := os.Getenv("MONOVIEW_TLS_CERT")
 	defaultTLSKey := os.Getenv("MONOVIEW_TLS_KEY")
 	defaultTLSCA := os.Getenv("MONOVIEW_TLS_CA")
 	defaultTLSServerName := os.Getenv("MONOVIEW_TLS_SERVER_NAME")
+	defaultSnooze, err := time.ParseDuration(envOr("MONOVIEW_SNOOZE", "5m"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "MONOVIEW_SNOOZE: %v\n", err)
+		os.Exit(1)
+	}
+	defaultConfigPath := os.Getenv("MONOVIEW_CONFIG")
+	if defaultConfigPath == "" {
+		defaultConfigPath, _ = config.DefaultPath()
+	}
+	if len(os.Args) > 1 && os.Args[1] == "profile" {
+		os.Exit(runProfile(os.Args[2:], defaultConfigPath))
+	}
+	defaultPomodoro := envOr("MONOVIEW_POMODORO", "25m/5m/15m/4")
+	defaultPomodoroDim, err := strconv.Atoi(envOr("MONOVIEW_POMODORO_DIM", "-1"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "MONOVIEW_POMODORO_DIM: %v\n", err)
+		os.Exit(1)
+	}
+	defaultHub := os.Getenv("MONOVIEW_HUB")
+	defaultKiosk := os.Getenv("MONOVIEW_KIOSK") != ""
+	defaultKioskRotate, err := time.ParseDuration(envOr("MONOVIEW_KIOSK_ROTATE", "30s"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "MONOVIEW_KIOSK_ROTATE: %v\n", err)
+		os.Exit(1)
+	}
+	defaultKioskSheets := envOr("MONOVIEW_KIOSK_SHEETS", "7,1,4")
+	defaultKioskNight := envOr("MONOVIEW_KIOSK_NIGHT", "22:00-07:00")
 
 	url := cli.StringP("url", "u", defaultURLVal, "Url of hub (env MONOVIEW_URL)")
 	tlsCert := cli.String("tls-cert", defaultTLSCert, "Client certificate PEM for mTLS (wss) (env MONOVIEW_TLS_CERT)")
@@ -36,75 +80,246 @@ func main() {
 	tlsCA := cli.String("tls-ca", defaultTLSCA, "Optional CA PEM to verify server; default system roots (env MONOVIEW_TLS_CA)")
 	tlsServerName := cli.String("tls-server-name", defaultTLSServerName, "TLS ServerName (SNI); use when URL is an IP (env MONOVIEW_TLS_SERVER_NAME)")
 	logPath := cli.String("log-path", defaultLogPath, "Path to log file (env MONOVIEW_LOG)")
+	logLevel := cli.String("log-level", defaultLogLevel, "Least level logged: debug, info, warn or error (env MONOVIEW_LOG_LEVEL)")
+	logFormat := cli.String("log-format", defaultLogFormat, "Log line format: text or json (env MONOVIEW_LOG_FORMAT)")
+	logMaxSize := cli.String("log-max-size", defaultLogMaxSize, "Rotate the log file at this size, e.g. 10MB; 0 never (env MONOVIEW_LOG_MAX_SIZE)")
+	logKeep := cli.Int("log-keep", defaultLogKeep, "Rotated log files kept as <log-path>.1 … .N (env MONOVIEW_LOG_KEEP)")
+	snooze := cli.Duration("snooze", defaultSnooze, "How far [s] on the fire alert re-arms the job (env MONOVIEW_SNOOZE)")
+	configPath := cli.String("config", defaultConfigPath, "Path to JSON config file; missing file uses defaults (env MONOVIEW_CONFIG)")
+	pomodoro := cli.String("pomodoro", defaultPomodoro, "Pomodoro cycle work/short/long/every (env MONOVIEW_POMODORO)")
+	pomodoroDim := cli.Int("pomodoro-dim", defaultPomodoroDim, "VERTEX LED brightness during pomodoro breaks, -1 to leave as is (env MONOVIEW_POMODORO_DIM)")
+	hubName := cli.String("hub", defaultHub, "Hub from the config's \"hubs\" to show first; default the config's \"hub\" (env MONOVIEW_HUB)")
+	kiosk := cli.Bool("kiosk", defaultKiosk, "Read-only display: no commands or toggles, rotating sheets, big clock (env MONOVIEW_KIOSK)")
+	kioskRotate := cli.Duration("kiosk-rotate", defaultKioskRotate, "Time on each sheet in kiosk mode, 0 to stay (env MONOVIEW_KIOSK_ROTATE)")
+	kioskSheets := cli.String("kiosk-sheets", defaultKioskSheets, "Sheet numbers to rotate through in kiosk mode (env MONOVIEW_KIOSK_SHEETS)")
+	kioskNight := cli.String("kiosk-night", defaultKioskNight, "Hours HH:MM-HH:MM the kiosk screen is dimmed, empty for never (env MONOVIEW_KIOSK_NIGHT)")
 	cli.Parse()
 
-	logFile, err := os.OpenFile(*logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	pomodoroPlan, err := app.ParsePomodoroPlan(*pomodoro)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "--pomodoro: %v\n", err)
+		os.Exit(1)
+	}
+	pomodoroPlan.DimTo = *pomodoroDim
+
+	kioskMode := types.Kiosk{Enabled: *kiosk, Rotate: *kioskRotate}
+	if kioskMode.Sheets, err = app.ParseKioskSheets(*kioskSheets); err != nil {
+		fmt.Fprintf(os.Stderr, "--kiosk-sheets: %v\n", err)
+		os.Exit(1)
+	}
+	if kioskMode.NightFrom, kioskMode.NightTo, err = app.ParseNightHours(*kioskNight); err != nil {
+		fmt.Fprintf(os.Stderr, "--kiosk-night: %v\n", err)
+		os.Exit(1)
+	}
+
+	conf, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "config: %v\n", err)
+		os.Exit(1)
+	}
+	// The UI and the bell/OSC notifiers share stdout; see notify.Terminal.
+	terminal := notify.NewTerminal(os.Stdout)
+	notifier, err := notify.NewDispatcher(conf.Notify, terminal, conf.NotifyHook)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "config: notify: %v\n", err)
+		os.Exit(1)
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(*logLevel)); err != nil {
+		fmt.Fprintf(os.Stderr, "--log-level: %v\n", err)
+		os.Exit(1)
+	}
+	maxSize, err := parseByteSize(*logMaxSize)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "--log-max-size: %v\n", err)
+		os.Exit(1)
+	}
+	logFile, err := logfile.Open(*logPath, maxSize, *logKeep)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "cannot open log file %s: %v\n", *logPath, err)
 		os.Exit(1)
 	}
 	defer logFile.Close()
 
-	logger := log.New(logFile, "", log.LstdFlags)
-	logger.Printf("monoview starting, url=%s", *url)
+	opts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch *logFormat {
+	case "text":
+		handler = slog.NewTextHandler(logFile, opts)
+	case "json":
+		handler = slog.NewJSONHandler(logFile, opts)
+	default:
+		fmt.Fprintf(os.Stderr, "--log-format: want text or json, got %q\n", *logFormat)
+		os.Exit(1)
+	}
+	logger := slog.New(handler)
+	slog.SetDefault(logger)
+	app.SetLogger(logger)
+	logger.Info("monoview starting", "url", *url, "config", *configPath)
 
-	var hubOpts []monolink.Option
-	hubOpts = append(hubOpts,
-		monolink.WithInbox(64),
-		monolink.WithLogger(logger),
-	)
+	profiles := conf.Hubs
+	if len(profiles) == 0 {
+		if (*tlsCert == "") != (*tlsKey == "") {
+			fmt.Fprintln(os.Stderr, "mTLS requires both --tls-cert and --tls-key (or MONOVIEW_TLS_CERT and MONOVIEW_TLS_KEY)")
+			os.Exit(1)
+		}
+		profiles = []config.HubProfile{{
+			URL:        *url,
+			TLSCert:    *tlsCert,
+			TLSKey:     *tlsKey,
+			TLSCA:      *tlsCA,
+			ServerName: *tlsServerName,
+		}}
+	}
 
-	switch {
-	case *tlsCert != "" && *tlsKey != "":
-		cfg, err := monolink.LoadClientTLS(*tlsCert, *tlsKey, *tlsCA)
+	ctx := context.Background()
+	var (
+		p      *tea.Program
+		hubsMu sync.Mutex
+	)
+	hubs := make([]*monolink.Client, len(profiles))
+	m := app.NewModel()
+	m.Config = conf
+	for i, prof := range profiles {
+		hubLog := logger.With("hub", prof.Name)
+		dial, cert, err := hubDialer(prof, hubLog)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "mTLS: %v\n", err)
+			hubLog.Error("bad TLS files", "err", err)
+			fmt.Fprintf(os.Stderr, "hub %s: %v\n", prof.Name, err)
 			os.Exit(1)
 		}
-		if *tlsServerName != "" {
-			cfg.ServerName = *tlsServerName
+		hubLog.Info("dialing", "url", prof.URL)
+		hub, dialErr := dial.Connect(ctx)
+		if dialErr != nil {
+			hubLog.Warn("concentrator offline", "url", prof.URL, "err", dialErr)
 		}
-		hubOpts = append(hubOpts, monolink.WithTLS(cfg))
-	case *tlsCert != "" || *tlsKey != "":
-		fmt.Fprintln(os.Stderr, "mTLS requires both --tls-cert and --tls-key (or MONOVIEW_TLS_CERT and MONOVIEW_TLS_KEY)")
+		hubs[i] = hub
+		m.AddHub(prof.Name, hub)
+		if prof.TLSCert != "" {
+			m.SetHubCert(prof.Name, cert)
+		}
+		// A hub reached later from the diagnostics view ([r]) needs its inbox
+		// read too, and replaces the client closed at exit; the model closes
+		// the old one, or the new one when it does not take it.
+		dial.Adopt = func(hub *monolink.Client) {
+			hubsMu.Lock()
+			hubs[i] = hub
+			hubsMu.Unlock()
+			forwardInbox(p, prof.Name, hub)
+		}
+		m.SetHubDial(prof.Name, dial, dialErr)
+	}
+	startHub := *hubName
+	if startHub == "" {
+		startHub = conf.Hub
+	}
+	if startHub != "" && !m.UseHub(startHub) {
+		fmt.Fprintf(os.Stderr, "--hub: no hub named %q in the config\n", startHub)
 		os.Exit(1)
 	}
-
-	hub := monolink.New(NodeName, *url, hubOpts...)
-
-	ctx := context.Background()
-	if err := hub.Connect(ctx); err != nil {
-		logger.Printf("concentrator offline: %v", err)
-		hub = nil
+	m.Notifier = notifier
+	m.SnoozeFor = *snooze
+	m.PomodoroPlan = pomodoroPlan
+	m.Kiosk = kioskMode
+	if kioskMode.Enabled {
+		m.ActiveSheet = kioskMode.Sheets[0]
 	}
 
-	m := app.NewModel()
-	m.Hub = hub
-
-	p := tea.NewProgram(m, tea.WithAltScreen())
-	if hub != nil {
-		if inbox := hub.Inbox(); inbox != nil {
-			go func() {
-				for {
-					msg, ok := <-inbox
-					if !ok {
-						return
-					}
-					p.Send(app.HubMsg(msg))
-				}
-			}()
-		}
+	p = tea.NewProgram(m, tea.WithAltScreen(), tea.WithOutput(terminal))
+	for i, hub := range hubs {
+		forwardInbox(p, profiles[i].Name, hub)
 	}
 	if _, err := p.Run(); err != nil {
-		logger.Printf("fatal: %v", err)
+		logger.Error("fatal", "err", err)
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	if hub != nil {
-		hub.Close()
+	hubsMu.Lock()
+	defer hubsMu.Unlock()
+	for _, hub := range hubs {
+		if hub != nil {
+			hub.Close()
+		}
+	}
+	logger.Info("monoview stopped")
+}
+
+// hubDialer returns how to reach one hub and its client certificate, if any.
+// Bad TLS files are an error; whether the hub answers is up to Connect, and
+// monoview starts either way.
+func hubDialer(prof config.HubProfile, logger *slog.Logger) (app.HubDial, creds.Info, error) {
+	hubOpts := []monolink.Option{
+		monolink.WithInbox(64),
+		// monolink logs through the standard logger; its lines come out at INFO.
+		monolink.WithLogger(slog.NewLogLogger(logger.Handler(), slog.LevelInfo)),
+	}
+	d := app.HubDial{URL: prof.URL, ServerName: prof.ServerName}
+	var cert creds.Info
+	switch {
+	case prof.TLSCert != "" && prof.TLSKey != "":
+		cfg, info, err := loadHubTLS(prof)
+		if err != nil {
+			return d, cert, fmt.Errorf("mTLS: %w", err)
+		}
+		cert, d.TLS = info, cfg
+		logger.Info("client certificate", "subject", info.Subject, "expires", info.NotAfter.Format(time.DateOnly))
+		hubOpts = append(hubOpts, monolink.WithTLS(cfg))
+	case prof.ServerName != "":
+		d.TLS = &tls.Config{ServerName: prof.ServerName, MinVersion: tls.VersionTLS12}
+		hubOpts = append(hubOpts, monolink.WithTLS(d.TLS))
+	}
+	d.Connect = func(ctx context.Context) (*monolink.Client, error) {
+		hub := monolink.New(NodeName, prof.URL, hubOpts...)
+		if err := hub.Connect(ctx); err != nil {
+			return nil, err
+		}
+		return hub, nil
+	}
+	return d, cert, nil
+}
+
+// forwardInbox hands the hub's messages to the program.
+func forwardInbox(p *tea.Program, name string, hub *monolink.Client) {
+	if hub == nil || hub.Inbox() == nil {
+		return
+	}
+	inbox := hub.Inbox()
+	go func() {
+		for {
+			msg, ok := <-inbox
+			if !ok {
+				return
+			}
+			p.Send(app.HubMsg{Hub: name, Msg: msg})
+		}
+	}()
+}
+
+// parseByteSize parses a size such as 512KB, 10MB or 1G (binary multiples; the B is optional).
+func parseByteSize(s string) (int64, error) {
+	num := strings.ToUpper(strings.TrimSpace(s))
+	num = strings.TrimSuffix(strings.TrimSuffix(num, "IB"), "B")
+	unit := int64(1)
+	if n := len(num); n > 0 {
+		switch num[n-1] {
+		case 'K':
+			unit = 1 << 10
+		case 'M':
+			unit = 1 << 20
+		case 'G':
+			unit = 1 << 30
+		}
+		if unit > 1 {
+			num = num[:n-1]
+		}
+	}
+	v, err := strconv.ParseInt(strings.TrimSpace(num), 10, 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
 	}
-	logger.Printf("monoview stopped")
+	return v * unit, nil
 }
 
 func envOr(key, fallback string) string {
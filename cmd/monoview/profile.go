@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	cli "github.com/spf13/pflag"
+
+	"monoview/internal/config"
+	"monoview/internal/creds"
+)
+
+const profileUsage = `usage: monoview profile <command> [--config FILE]
+
+  add NAME --url URL [--tls-cert FILE --tls-key FILE] [--tls-ca FILE] [--tls-server-name NAME] [--use]
+             add or replace a connection profile; TLS files are checked first
+  list       show the profiles; * marks the one used at startup
+  use NAME   connect to NAME first from now on
+  rm NAME    remove a profile
+`
+
+// runProfile implements `monoview profile`: the connection profiles are the
+// "hubs" of the config file, and "hub" is the one shown at startup.
+func runProfile(args []string, configPath string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprint(os.Stderr, profileUsage)
+		return 2
+	}
+	cmd := args[0]
+	fs := cli.NewFlagSet("profile "+cmd, cli.ContinueOnError)
+	path := fs.String("config", configPath, "Path to JSON config file (env MONOVIEW_CONFIG)")
+	var prof config.HubProfile
+	var use bool
+	if cmd == "add" {
+		fs.StringVar(&prof.URL, "url", "", "Url of hub")
+		fs.StringVar(&prof.TLSCert, "tls-cert", "", "Client certificate PEM for mTLS")
+		fs.StringVar(&prof.TLSKey, "tls-key", "", "Client private key PEM for mTLS, may be encrypted")
+		fs.StringVar(&prof.TLSCA, "tls-ca", "", "CA PEM to verify the server; default system roots")
+		fs.StringVar(&prof.ServerName, "tls-server-name", "", "TLS ServerName (SNI); use when URL is an IP")
+		fs.BoolVar(&use, "use", false, "Also make it the hub used at startup")
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+	conf, err := config.Load(*path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "config: %v\n", err)
+		return 1
+	}
+	hubs, active := conf.Hubs, conf.Hub
+
+	name := fs.Arg(0)
+	if cmd != "list" && (fs.NArg() != 1 || name == "") {
+		fmt.Fprint(os.Stderr, profileUsage)
+		return 2
+	}
+	i := conf.FindHub(name)
+	switch cmd {
+	case "list":
+		listProfiles(conf)
+		return 0
+	case "add":
+		prof.Name = name
+		if prof.URL == "" {
+			fmt.Fprintln(os.Stderr, "profile add: --url is required")
+			return 2
+		}
+		// Store absolute paths so the profile works from any directory.
+		for _, f := range []*string{&prof.TLSCert, &prof.TLSKey, &prof.TLSCA} {
+			if *f != "" {
+				if abs, err := filepath.Abs(*f); err == nil {
+					*f = abs
+				}
+			}
+		}
+		if prof.TLSCert != "" || prof.TLSKey != "" {
+			_, info, err := loadHubTLS(prof)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "profile %s: mTLS: %v\n", name, err)
+				return 1
+			}
+			fmt.Printf("client certificate %s, %s\n", info.Subject, describeExpiry(info))
+		}
+		if i >= 0 {
+			hubs[i] = prof
+		} else {
+			hubs = append(hubs, prof)
+		}
+		if use {
+			active = name
+		}
+	case "use":
+		if i < 0 {
+			fmt.Fprintf(os.Stderr, "profile use: no profile named %q\n", name)
+			return 1
+		}
+		active = hubs[i].Name
+	case "rm":
+		if i < 0 {
+			fmt.Fprintf(os.Stderr, "profile rm: no profile named %q\n", name)
+			return 1
+		}
+		hubs = append(hubs[:i], hubs[i+1:]...)
+		if strings.EqualFold(active, name) {
+			active = ""
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "profile: unknown command %q\n", cmd)
+		fmt.Fprint(os.Stderr, profileUsage)
+		return 2
+	}
+	if err := config.SaveHubs(*path, hubs, active); err != nil {
+		fmt.Fprintf(os.Stderr, "config: %v\n", err)
+		return 1
+	}
+	fmt.Printf("saved %s\n", *path)
+	return 0
+}
+
+// listProfiles prints one line per profile. The certificate is read without
+// the key, so encrypted keys are not asked for.
+func listProfiles(conf config.Config) {
+	if len(conf.Hubs) == 0 {
+		fmt.Println("no profiles; add one with `monoview profile add NAME --url URL`")
+		return
+	}
+	active := conf.Hub
+	if active == "" {
+		active = conf.Hubs[0].Name
+	}
+	for _, h := range conf.Hubs {
+		mark := " "
+		if strings.EqualFold(h.Name, active) {
+			mark = "*"
+		}
+		tlsInfo := "no client cert"
+		if h.TLSCert != "" {
+			if info, err := creds.CertInfo(h.TLSCert); err != nil {
+				tlsInfo = "mTLS: " + err.Error()
+			} else {
+				tlsInfo = "mTLS, " + describeExpiry(info)
+			}
+		}
+		fmt.Printf("%s %-12s %-32s %s\n", mark, h.Name, h.URL, tlsInfo)
+	}
+}
+
+func describeExpiry(info creds.Info) string {
+	days := info.DaysLeft(time.Now())
+	date := info.NotAfter.Format("2006-01-02")
+	if days < 0 {
+		return "EXPIRED " + date
+	}
+	return fmt.Sprintf("expires %s (%d days)", date, days)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+
+	"monoview/internal/config"
+	"monoview/internal/creds"
+)
+
+// passphraseTries is how often a wrong passphrase may be typed before giving up.
+const passphraseTries = 3
+
+// loadHubTLS loads and checks a profile's client certificate, key and CA.
+// The passphrase of an encrypted key comes from MONOVIEW_TLS_PASSPHRASE_<NAME>
+// or MONOVIEW_TLS_PASSPHRASE, else it is asked for on the terminal.
+func loadHubTLS(prof config.HubProfile) (*tls.Config, creds.Info, error) {
+	for try := 1; ; try++ {
+		prompted := false
+		cfg, info, err := creds.Load(prof, func(keyFile string) ([]byte, error) {
+			if p := passphraseEnv(prof.Name); p != "" {
+				return []byte(p), nil
+			}
+			prompted = true
+			return promptPassphrase(keyFile)
+		})
+		if errors.Is(err, creds.ErrPassphrase) && prompted && try < passphraseTries {
+			fmt.Fprintln(os.Stderr, "Wrong passphrase, try again.")
+			continue
+		}
+		return cfg, info, err
+	}
+}
+
+func passphraseEnv(profile string) string {
+	if profile != "" {
+		if p := os.Getenv("MONOVIEW_TLS_PASSPHRASE_" + strings.ToUpper(profile)); p != "" {
+			return p
+		}
+	}
+	return os.Getenv("MONOVIEW_TLS_PASSPHRASE")
+}
+
+func promptPassphrase(keyFile string) ([]byte, error) {
+	fd := os.Stdin.Fd()
+	if !term.IsTerminal(fd) {
+		return nil, fmt.Errorf("%s is encrypted: set MONOVIEW_TLS_PASSPHRASE", keyFile)
+	}
+	fmt.Fprintf(os.Stderr, "Passphrase for %s: ", keyFile)
+	pass, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	return pass, err
+}
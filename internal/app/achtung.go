@@ -0,0 +1,184 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"monoview/internal/types"
+	"monoview/internal/ui"
+)
+
+// ACHTUNG job visuals: progress bars for timers, a 24h timeline for alarms,
+// colours escalating towards the deadline, and the full-screen countdown ([f]).
+
+const (
+	achtungBarWidth      = 10
+	achtungTimelineWidth = 30
+	achtungBigBarWidth   = 40
+)
+
+// achtungJobLeft is the time until the job fires (the frozen remainder while paused).
+func (m Model) achtungJobLeft(j types.AchtungJob) (time.Duration, bool) {
+	if j.Paused {
+		return j.PausedLeft, true
+	}
+	if j.EndTime == nil {
+		return 0, false
+	}
+	now := m.LastUpdate
+	if now.IsZero() {
+		now = time.Now()
+	}
+	left := j.EndTime.Sub(now)
+	if left < 0 {
+		left = 0
+	}
+	return left, true
+}
+
+// achtungJobProgress is the elapsed share of a timer in percent; ok is false
+// for alarms and timers whose length is unknown.
+func (m Model) achtungJobProgress(j types.AchtungJob) (float64, bool) {
+	left, ok := m.achtungJobLeft(j)
+	if !ok || j.Kind != "TIMER" || j.Total <= 0 {
+		return 0, false
+	}
+	pct := float64(j.Total-left) / float64(j.Total) * 100
+	return ui.Clamp(pct, 0, 100), true
+}
+
+// achtungUrgency is 0 (plenty of time), 1 (soon: under 5m or 70%) or 2 (imminent: under 1m or 90%).
+func (m Model) achtungUrgency(j types.AchtungJob) int {
+	left, ok := m.achtungJobLeft(j)
+	if !ok || j.Paused {
+		return 0
+	}
+	pct, _ := m.achtungJobProgress(j)
+	switch {
+	case left <= time.Minute || pct >= 90:
+		return 2
+	case left <= 5*time.Minute || pct >= 70:
+		return 1
+	}
+	return 0
+}
+
+// achtungUrgencyStyle escalates from normal to yellow to red as the job nears its end.
+func (m Model) achtungUrgencyStyle(j types.AchtungJob) lipgloss.Style {
+	if j.Paused {
+		return ui.Warning
+	}
+	switch m.achtungUrgency(j) {
+	case 2:
+		return ui.Offline
+	case 1:
+		return ui.Warning
+	}
+	return ui.Value
+}
+
+// renderAchtungJobLine draws one job: timers with a progress bar, alarms with their due time.
+func (m Model) renderAchtungJobLine(j types.AchtungJob) string {
+	kindStyle := ui.Label
+	if j.Kind == "ALARM" {
+		kindStyle = ui.Accent
+	}
+	if j.Paused {
+		kindStyle = ui.Warning
+	}
+	line := kindStyle.Render(j.Kind+":") + " " + ui.Value.Render(ui.TruncateString(j.Name, 16)) + "  "
+	if pct, ok := m.achtungJobProgress(j); ok {
+		line += ui.RenderBar(pct, achtungBarWidth) + " "
+	} else if j.Kind == "ALARM" && j.EndTime != nil {
+		line += ui.Label.Render(j.EndTime.Format("15:04")) + " "
+	} else if j.Due != "" && j.Due != "—" {
+		line += ui.Label.Render("due:") + " " + ui.Value.Render(j.Due) + " "
+	}
+	line += m.achtungUrgencyStyle(j).Render(j.Remaining)
+	if r := m.recurringAlarm(j.Name); r != nil {
+		line += "  " + ui.Accent.Render("↻ "+repeatLabel(r.Days))
+	}
+	return line
+}
+
+// renderAlarmTimeline draws the next 24 hours with ◆ for each armed alarm and
+// ┊ at midnight. Returns "" when no alarm falls in that window.
+func (m Model) renderAlarmTimeline() string {
+	now := m.LastUpdate
+	if now.IsZero() {
+		now = time.Now()
+	}
+	slot := 24 * time.Hour / achtungTimelineWidth
+	cells := make([]string, achtungTimelineWidth)
+	for i := range cells {
+		cells[i] = ui.Dim.Render("┄")
+	}
+	midnight := startOfDay(now).AddDate(0, 0, 1)
+	if i := int(midnight.Sub(now) / slot); i >= 0 && i < len(cells) {
+		cells[i] = ui.Dim.Render("┊")
+	}
+	found := false
+	for idx, j := range m.AchtungJobs {
+		if j.Kind != "ALARM" || j.EndTime == nil {
+			continue
+		}
+		d := j.EndTime.Sub(now)
+		if d < 0 || d >= 24*time.Hour {
+			continue
+		}
+		style := ui.Accent
+		if m.achtungUrgency(j) > 0 {
+			style = m.achtungUrgencyStyle(j)
+		}
+		if idx == m.SelectedAchtungJob {
+			style = style.Bold(true).Underline(true)
+		}
+		cells[int(d/slot)] = style.Render("◆")
+		found = true
+	}
+	if !found {
+		return ""
+	}
+	end := now.Add(24 * time.Hour)
+	return "  " + ui.Label.Render(now.Format("15:04")) + " " + strings.Join(cells, "") + " " + ui.Label.Render(end.Format("15:04"))
+}
+
+// bigClock formats a duration as H:MM:SS (hours may exceed 24).
+func bigClock(d time.Duration) string {
+	d = d.Round(time.Second)
+	h := int(d.Hours())
+	mins := int(d.Minutes()) % 60
+	secs := int(d.Seconds()) % 60
+	return fmt.Sprintf("%d:%02d:%02d", h, mins, secs)
+}
+
+// renderAchtungBigView is the full-screen countdown of the selected job.
+func (m Model) renderAchtungBigView() string {
+	if m.SelectedAchtungJob < 0 || m.SelectedAchtungJob >= len(m.AchtungJobs) {
+		return lipgloss.Place(m.Width, m.Height, lipgloss.Center, lipgloss.Center,
+			ui.Dim.Render("No timer or alarm selected  [f] / [Esc] close"))
+	}
+	j := m.AchtungJobs[m.SelectedAchtungJob]
+	style := m.achtungUrgencyStyle(j)
+	lines := []string{ui.Title.Render(" " + j.Kind + "  " + j.Name + " "), ""}
+	if left, ok := m.achtungJobLeft(j); ok {
+		lines = append(lines, style.Render(ui.BigText(bigClock(left))))
+	} else {
+		lines = append(lines, style.Render(j.Remaining))
+	}
+	lines = append(lines, "")
+	if pct, ok := m.achtungJobProgress(j); ok {
+		lines = append(lines, ui.RenderBar(pct, achtungBigBarWidth)+ui.Dim.Render(fmt.Sprintf(" %3.0f%%", pct)))
+	} else if j.EndTime != nil {
+		lines = append(lines, ui.Label.Render("at ")+ui.Value.Render(j.EndTime.Format("Mon 02 Jan 15:04")))
+	}
+	if j.Paused {
+		lines = append(lines, ui.Warning.Render("paused"))
+	}
+	lines = append(lines, "", ui.Dim.Render("[j/k] job  [p] pause  [e] +5m  [f] / [Esc] close"))
+	block := lipgloss.JoinVertical(lipgloss.Center, lines...)
+	return lipgloss.Place(m.Width, m.Height, lipgloss.Center, lipgloss.Center, block)
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"monoview/internal/types"
+	"monoview/internal/ui"
+)
+
+// renderAlertBanner is the header line for unacknowledged alerts: the newest
+// one and how many more. Empty when there is nothing to acknowledge.
+func (m Model) renderAlertBanner(width int) string {
+	n := m.unackedAlerts()
+	if n == 0 || width < 12 {
+		return ""
+	}
+	var text string
+	for i := len(m.Alerts) - 1; i >= 0; i-- {
+		if a := m.Alerts[i]; !a.Acked {
+			text = fmt.Sprintf("%s %s: %s", alertNode(a), a.Label, a.Detail)
+			break
+		}
+	}
+	if n > 1 {
+		text += fmt.Sprintf(" (+%d)", n-1)
+	}
+	text = ui.TruncateString("⚠ "+text+"  [!]", width-2)
+	style := lipgloss.NewStyle().Foreground(ui.GruvBg).Background(ui.GruvRed).Bold(true)
+	return style.Render(" " + text + " ")
+}
+
+// alertNode is the alert's node, as HUB/NODE when there are several hubs.
+func alertNode(a types.NodeAlert) string {
+	if a.Hub == "" {
+		return a.Node
+	}
+	return a.Hub + "/" + a.Node
+}
+
+func (m Model) renderAlertsPanel(minHeight int) string {
+	const width = 64
+	var lines []string
+	lines = append(lines, "")
+	lines = append(lines, ui.Title.Render(fmt.Sprintf("  Alerts (%d)", len(m.Alerts)))+" ")
+	lines = append(lines, ui.Label.Render("  Raised by the alert rules in the config file"))
+	lines = append(lines, "")
+	if len(m.Alerts) == 0 {
+		lines = append(lines, ui.Dim.Render("  No alerts"))
+	}
+	for i, a := range m.Alerts {
+		prefix := "  "
+		if i == m.SelectedAlert {
+			prefix = "▌ "
+		}
+		style := ui.Offline
+		state := "active"
+		switch {
+		case a.Acked:
+			style, state = ui.Dim, "acked"
+		case !a.Resolved.IsZero():
+			style, state = ui.Warning, "resolved "+a.Resolved.Format("15:04")
+		}
+		line := prefix + ui.Label.Render(a.Raised.Format("15:04:05")) + " " + style.Render(alertNode(a)+" "+a.Label)
+		lines = append(lines, ui.TruncateString(line, width-3))
+		lines = append(lines, ui.TruncateString("           "+ui.Value.Render(a.Detail)+"  "+ui.Dim.Render(state), width-3))
+	}
+	lines = append(lines, "")
+	lines = append(lines, ui.Dim.Render("  [↑/↓] select  [Enter] acknowledge  [A] all  [Esc] close"))
+	inner := strings.Join(lines, "\n")
+	if minHeight > 2 {
+		inner = padToLines(inner, minHeight-2)
+	}
+	box := ui.NewBox(width).WithBorderColor(ui.GruvRed).WithTitle(" ALERTS ")
+	return box.Render(inner)
+}
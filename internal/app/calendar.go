@@ -107,7 +107,7 @@ func (m Model) renderEventList() string {
 	var lines []string
 
 	titleText := "EVENTS: " + m.SelectedDate.Format("02 Jan")
-	lines = append(lines, ui.PadLine(" "+ui.Title.Render(titleText), inner))
+	lines = append(lines, ui.PadLine(" "+ui.Title.Render(titleText)+"  "+staleMarker(m.CalendarStaleSince), inner))
 	if m.CalendarFocusEvents {
 		lines = append(lines, ui.PadLine(" "+ui.Dim.Render("↑/↓ select  [d] delete  [Esc] back"), inner))
 	} else {
@@ -161,12 +161,19 @@ func getCategoryIcon(cat string) string {
 		return lipgloss.NewStyle().Foreground(ui.GruvRed).Render("●")
 	case "system":
 		return lipgloss.NewStyle().Foreground(ui.GruvPurple).Render("●")
+	case "holiday":
+		return lipgloss.NewStyle().Foreground(ui.GruvYellow).Render("●")
 	default:
 		return lipgloss.NewStyle().Foreground(ui.GruvGray).Render("●")
 	}
 }
 
 func (m Model) renderDeadlines() string {
+	return m.renderUpcomingDeadlines(5)
+}
+
+// renderUpcomingDeadlines boxes the next maxDeadlines open deadlines.
+func (m Model) renderUpcomingDeadlines(maxDeadlines int) string {
 	width := 40
 	inner := width - 3 // 2 for borders, 1 for left padding
 
@@ -175,24 +182,18 @@ func (m Model) renderDeadlines() string {
 	lines = append(lines, ui.PadLine(" "+ui.Title.Render("UPCOMING DEADLINES"), inner))
 	lines = append(lines, "")
 
-	now := time.Now()
-	const maxDeadlines = 5
 	count := 0
 	for _, e := range m.Deadlines {
-		if !e.Date.After(now) {
+		if m.deadlineIsDone(e) {
 			continue
 		}
-		days := int(e.Date.Sub(now).Hours() / 24)
-		var daysStr string
-		if days == 0 {
-			daysStr = ui.Warning.Render("TODAY")
-		} else if days == 1 {
-			daysStr = ui.Warning.Render("  1d ")
-		} else {
-			daysStr = ui.Label.Render(fmt.Sprintf("%3dd ", days))
+		countdown := fmt.Sprintf("%7s", formatDeadlineCountdown(e.Date.Sub(m.LastUpdate)))
+		titleStyle := ui.Value
+		if !e.Date.After(m.LastUpdate) {
+			titleStyle = ui.Offline
 		}
 
-		line := fmt.Sprintf(" %s %s", daysStr, ui.Value.Render(e.Title))
+		line := fmt.Sprintf(" %s %s", m.deadlineCountdownStyle(e).Render(countdown), titleStyle.Render(e.Title))
 		lines = append(lines, ui.PadLine(line, inner))
 		count++
 		if count >= maxDeadlines {
@@ -220,9 +221,10 @@ func (m Model) renderSchedule() string {
 
 	// Header with weekday name
 	weekdayName := m.SelectedDate.Weekday().String()
-	header := fmt.Sprintf(" %s  %s",
+	header := fmt.Sprintf(" %s  %s  %s",
 		ui.Title.Render("SCHEDULE"),
-		ui.Accent.Render(weekdayName))
+		ui.Accent.Render(weekdayName),
+		staleMarker(m.CalendarStaleSince))
 	lines = append(lines, ui.PadLine(header, inner))
 	lines = append(lines, ui.PadLine(" "+ui.Dim.Render(strings.Repeat("─", width-4)), inner))
 
@@ -323,6 +325,10 @@ func (m Model) isCurrentClass(e types.ScheduleEntry, now time.Time) bool {
 	nowMins := now.Hour()*60 + now.Minute()
 	startMins := startH*60 + startM
 	endMins := endH*60 + endM
+	if endMins <= startMins {
+		// Runs past midnight: the rest of the day is in it.
+		endMins = 24 * 60
+	}
 
 	return nowMins >= startMins && nowMins <= endMins
 }
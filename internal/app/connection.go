@@ -0,0 +1,129 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"monoview/internal/diag"
+	"monoview/internal/ui"
+)
+
+// renderConnection shows how the active hub is dialed, its last error and
+// the latest probe ([c] on the System sheet).
+func (m Model) renderConnection() string {
+	width := max(m.Width-6, 40)
+	hint := "[r] retry now  [c/Esc] back"
+	if len(m.Hubs) > 1 {
+		hint += "  [@] next hub"
+	}
+	title := "▌CONNECTION"
+	if m.Name != "" {
+		title += " " + strings.ToUpper(m.Name)
+	}
+	header := ui.Title.Render(title) + " " + ui.Dim.Render(hint) + "\n\n"
+
+	row := func(label, value string) string {
+		return ui.TruncateString(ui.Label.Render(fmt.Sprintf("  %-13s", label))+value, width)
+	}
+	state := ui.Offline.Render("● offline")
+	switch {
+	case m.hubConnected():
+		state = ui.Online.Render("● online")
+	case m.dialing:
+		state = ui.Warning.Render("● dialing…")
+	case m.Hub == nil:
+		state += ui.Dim.Render("  (not reached since start; [r] dials again)")
+	default:
+		state += ui.Dim.Render("  ([r] reconnects)")
+	}
+	lines := []string{row("Hub", state), row("URL", ui.Value.Render(orText(m.Dial.URL, "—")))}
+	if m.Dial.ServerName != "" {
+		lines = append(lines, row("Server name", ui.Value.Render(m.Dial.ServerName)))
+	}
+	if c := m.Cert; !c.NotAfter.IsZero() {
+		lines = append(lines, row("Client cert", ui.Value.Render(c.Subject)+"  "+renderCertExpiry(c, m.LastUpdate)))
+	}
+	lastErr := ui.Dim.Render("none")
+	if m.LastErr != "" {
+		lastErr = ui.Label.Render(m.LastErrAt.Format("15:04:05")) + "  " + ui.Offline.Render(m.LastErr)
+	}
+	lines = append(lines, row("Last error", lastErr), "")
+
+	probe := ui.Title.Render("▌PROBE")
+	switch {
+	case m.diagRunning:
+		probe += " " + ui.Warning.Render("running…")
+	case m.Diag != nil:
+		probe += " " + ui.Dim.Render(m.Diag.At.Format("15:04:05"))
+	}
+	lines = append(lines, probe)
+	if m.Diag == nil {
+		if m.Dial.URL == "" {
+			lines = append(lines, ui.Dim.Render("  No dial settings for this hub."))
+		} else if !m.diagRunning {
+			lines = append(lines, ui.Dim.Render("  [r] to probe"))
+		}
+		return ui.IndentLines(header+strings.Join(lines, "\n"), "  ")
+	}
+	r := m.Diag
+	for _, s := range r.Steps {
+		mark, detail := ui.Online.Render("✓"), ui.Value.Render(s.Detail)
+		switch {
+		case s.Skipped:
+			mark = ui.Dim.Render("·")
+			detail = ui.Dim.Render(orText(s.Detail, "skipped"))
+		case !s.OK:
+			mark, detail = ui.Offline.Render("✗"), ui.Offline.Render(s.Detail)
+		}
+		took := ""
+		if !s.Skipped {
+			took = "  " + ui.Dim.Render(fmt.Sprintf("%dms", s.Took.Milliseconds()))
+		}
+		lines = append(lines, ui.TruncateString(fmt.Sprintf("  %s %s %s%s", mark, ui.Label.Render(fmt.Sprintf("%-10s", s.Name)), detail, took), width))
+	}
+
+	if len(r.Chain) > 0 || r.ClientCert != "" {
+		lines = append(lines, "", ui.Title.Render("▌TLS")+" "+ui.Dim.Render("checked against "+r.ServerName))
+	}
+	for i, c := range r.Chain {
+		lines = append(lines,
+			ui.TruncateString(fmt.Sprintf("  %s %s", ui.Label.Render(fmt.Sprintf("%d", i)), ui.Value.Render(c.Subject)), width),
+			ui.TruncateString("    "+ui.Dim.Render("issuer ")+c.Issuer+ui.Dim.Render("  expires ")+renderChainExpiry(c, m)+namesOf(c), width))
+	}
+	if r.NameMismatch != "" {
+		lines = append(lines, ui.TruncateString("  "+ui.Offline.Render("✗ name mismatch: "+r.NameMismatch), width))
+	}
+	if r.ClientCert != "" {
+		style := ui.Value
+		switch {
+		case r.ClientCert == "accepted":
+			style = ui.Online
+		case strings.HasPrefix(r.ClientCert, "rejected"), strings.Contains(r.ClientCert, "none is configured"):
+			style = ui.Offline
+		}
+		lines = append(lines, ui.TruncateString(ui.Label.Render("  Client cert  ")+style.Render(r.ClientCert), width))
+	}
+	return ui.IndentLines(header+strings.Join(lines, "\n"), "  ")
+}
+
+func renderChainExpiry(c diag.Cert, m Model) string {
+	s := c.NotAfter.Format("2006-01-02")
+	if c.NotAfter.Before(m.LastUpdate) {
+		return ui.Offline.Render(s + " (expired)")
+	}
+	return s
+}
+
+func namesOf(c diag.Cert) string {
+	if len(c.Names) == 0 {
+		return ""
+	}
+	return ui.Dim.Render("  names ") + strings.Join(c.Names, ", ")
+}
+
+func orText(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
@@ -0,0 +1,205 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"monoview/internal/config"
+	"monoview/internal/props"
+	"monoview/internal/ui"
+)
+
+// renderDashboard lays the configured widgets out row by row; a widget that
+// does not fit in the rest of a row starts the next one.
+func (m Model) renderDashboard() string {
+	d := m.Config.Dashboard
+	if len(d.Widgets) == 0 {
+		return ui.IndentLines(ui.Dim.Render("No widgets. Add them under \"dashboard\" in the config."), "  ")
+	}
+	cols := max(d.Columns, 1)
+	total := max(m.Width-4, 60)
+	colWidth := max((total-2*(cols-1))/cols, 24)
+
+	var rows, row []string
+	used := 0
+	for _, w := range d.Widgets {
+		span := min(max(w.Span, 1), cols)
+		if used+span > cols {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+			row, used = nil, 0
+		}
+		width := colWidth*span + 2*(span-1)
+		cell := lipgloss.PlaceHorizontal(width, lipgloss.Left, m.renderWidget(w, width))
+		if used > 0 {
+			row = append(row, "  ")
+		}
+		row = append(row, cell)
+		used += span
+	}
+	rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	return ui.IndentLines(strings.Join(rows, "\n"), "  ")
+}
+
+// renderWidget draws one widget width cells wide. Schedule and deadlines
+// reuse the Calendar boxes and keep their own width and title.
+func (m Model) renderWidget(w config.Widget, width int) string {
+	title := func(def string) string {
+		if w.Title != "" {
+			return w.Title
+		}
+		return def
+	}
+	box := func(def, content string) string {
+		return ui.NewBox(width).WithTitle(title(def)).WithLeftPadding(1).Render(content)
+	}
+	switch w.Type {
+	case "clock":
+		return box("CLOCK", m.renderClockWidget(width-3))
+	case "schedule":
+		today := m
+		today.SelectedDate = m.LastUpdate
+		return today.renderSchedule()
+	case "deadlines":
+		return m.renderUpcomingDeadlines(rowsOr(w.Rows, 5))
+	case "achtung":
+		lines := m.achtungLines()
+		if len(lines) == 0 {
+			lines = []string{ui.Dim.Render(" No timers or alarms.")}
+		}
+		return box("ACHTUNG", strings.Join(lines, "\n"))
+	case "devices":
+		var parts []string
+		for _, node := range m.widgetNodes(w, true) {
+			parts = append(parts, ui.Accent.Render(node), m.renderDevicesForNode(node))
+		}
+		return box("DEVICES", strings.Join(parts, "\n"))
+	case "nodes":
+		return m.renderNodesWidget(w, width)
+	case "sparkline":
+		return box("RTT", m.renderSparklineWidget(w, width-3))
+	case "logs":
+		return box("RECENT LOGS", m.renderLogsWidget(rowsOr(w.Rows, 6), width-3))
+	case "property":
+		p, _ := props.ParsePath(w.Property)
+		content := ui.Dim.Render("no value yet")
+		if v, ok := m.Props.Get(p); ok {
+			content = ui.Value.Render(v.Value) + "  " + ui.Dim.Render(propAge(m.LastUpdate, v.At))
+		}
+		return box(p.String(), content)
+	}
+	return box(strings.ToUpper(w.Type), ui.Dim.Render("unknown widget"))
+}
+
+func rowsOr(rows, def int) int {
+	if rows > 0 {
+		return rows
+	}
+	return def
+}
+
+// widgetNodes returns the widget's node, or all System nodes (only those with
+// Home devices when devices is set).
+func (m Model) widgetNodes(w config.Widget, devices bool) []string {
+	if w.Node != "" {
+		return []string{strings.ToUpper(w.Node)}
+	}
+	var out []string
+	for _, n := range m.Nodes {
+		if !devices || m.nodeHasDevices(n.Name) {
+			out = append(out, n.Name)
+		}
+	}
+	return out
+}
+
+func (m Model) nodeHasDevices(node string) bool {
+	for _, d := range m.HomeDevices {
+		if strings.EqualFold(d.Node, node) {
+			return true
+		}
+	}
+	return false
+}
+
+// renderClockWidget shows the time in big digits when they fit, and the date.
+func (m Model) renderClockWidget(inner int) string {
+	clock := ui.BigText(m.LastUpdate.Format("15:04"))
+	if lipgloss.Width(clock) > inner {
+		clock = ui.Value.Render(m.LastUpdate.Format("15:04:05"))
+	} else {
+		clock = ui.Accent.Render(clock)
+	}
+	return clock + "\n\n" + ui.Label.Render(m.LastUpdate.Format("Mon, 02 Jan 2006"))
+}
+
+// renderNodesWidget puts node panels side by side, wrapping to the widget width.
+func (m Model) renderNodesWidget(w config.Widget, width int) string {
+	var rows, row []string
+	used := 0
+	for _, name := range m.widgetNodes(w, false) {
+		i := m.nodeIndex(name)
+		if i < 0 {
+			continue
+		}
+		// The panel ends in a space that separates it from the next.
+		panel := m.renderNodePanel(m.Nodes[i], false)
+		pw := lipgloss.Width(panel)
+		if used > 0 && used+pw > width+1 {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+			row, used = nil, 0
+		}
+		row = append(row, panel)
+		used += pw
+	}
+	if len(row) == 0 && len(rows) == 0 {
+		return ui.Dim.Render("  No nodes")
+	}
+	rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	return strings.Join(rows, "\n")
+}
+
+// renderSparklineWidget draws a line per node: name, RTT sparkline, last RTT.
+func (m Model) renderSparklineWidget(w config.Widget, inner int) string {
+	const nameWidth, rttWidth = 10, 7
+	spark := max(inner-nameWidth-rttWidth-2, 8)
+	var lines []string
+	for _, name := range m.widgetNodes(w, false) {
+		i := m.nodeIndex(name)
+		if i < 0 {
+			continue
+		}
+		n := m.Nodes[i]
+		rtt := "—"
+		if (n.Status == "online" || n.Status == "degraded") && n.PingMs > 0 {
+			rtt = fmt.Sprintf("%dms", n.PingMs)
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %s",
+			ui.Label.Render(fmt.Sprintf("%-*s", nameWidth, ui.TruncateString(n.Name, nameWidth))),
+			ui.Sparkline(nodeRTTs(m.NodeHistory[n.Name], spark), spark),
+			ui.Value.Render(fmt.Sprintf("%*s", rttWidth, rtt))))
+	}
+	if len(lines) == 0 {
+		return ui.Dim.Render("No nodes")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderLogsWidget shows the newest n log entries.
+func (m Model) renderLogsWidget(n, inner int) string {
+	logs := m.Logs
+	if len(logs) > n {
+		logs = logs[:n]
+	}
+	if len(logs) == 0 {
+		return ui.Dim.Render("No logs yet")
+	}
+	// Time, level and source take 25 cells, the "..." 3.
+	msgWidth := max(inner-28, 10)
+	lines := make([]string, len(logs))
+	for i, l := range logs {
+		lines[i] = renderLogLine(l, msgWidth)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,133 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"monoview/internal/types"
+	"monoview/internal/ui"
+)
+
+func (m Model) renderDeadlineSheet() string {
+	list := m.renderDeadlineList()
+	burnDown := m.renderBurnDown()
+
+	content := lipgloss.JoinHorizontal(lipgloss.Top, list, "   ", burnDown)
+	return ui.IndentLines(content, "  ")
+}
+
+func (m Model) renderDeadlineList() string {
+	width := 64
+	inner := width - 3 // 2 for borders, 1 for left padding
+
+	var lines []string
+
+	header := fmt.Sprintf(" %s  %s %s  %s %s",
+		ui.Title.Render("DEADLINES"),
+		ui.Label.Render("sort:"),
+		ui.Accent.Render(deadlineSortNames[m.DeadlineSort%len(deadlineSortNames)]),
+		ui.Label.Render("filter:"),
+		ui.Accent.Render(deadlineFilterNames[m.DeadlineFilter%len(deadlineFilterNames)]))
+	lines = append(lines, ui.PadLine(header, inner))
+	lines = append(lines, ui.PadLine(" "+ui.Dim.Render(strings.Repeat("─", width-4)), inner))
+
+	now := m.LastUpdate
+	list := m.visibleDeadlines()
+	for i, e := range list {
+		done := m.deadlineIsDone(e)
+		check := ui.Label.Render("[ ]")
+		if done {
+			check = ui.Online.Render("[x]")
+		}
+
+		left := e.Date.Sub(now)
+		countdown := fmt.Sprintf("%-9s", formatDeadlineCountdown(left))
+		countdownStyle := m.deadlineCountdownStyle(e)
+		titleStyle := ui.Value
+		if done {
+			countdownStyle = ui.Dim
+			titleStyle = ui.Label.Strikethrough(true)
+		} else if left < 0 {
+			titleStyle = ui.Offline
+		}
+
+		prefix := " "
+		if i == m.SelectedDeadline {
+			prefix = lipgloss.NewStyle().Foreground(ui.GruvOrange).Bold(true).Render("▶")
+		}
+		line := fmt.Sprintf("%s %s %s %s  %s",
+			prefix,
+			check,
+			ui.Label.Render(e.Date.Format("02 Jan 15:04")),
+			countdownStyle.Render(countdown),
+			titleStyle.Render(e.Title))
+		lines = append(lines, ui.PadLine(line, inner))
+	}
+
+	if len(list) == 0 {
+		lines = append(lines, "")
+		lines = append(lines, ui.PadLine(" "+ui.Label.Render("No deadlines match filter"), inner))
+	}
+
+	content := strings.Join(lines, "\n")
+	return ui.NewBox(width).WithLeftPadding(1).Render(content)
+}
+
+func (m Model) renderBurnDown() string {
+	width := 40
+	inner := width - 3 // 2 for borders, 1 for left padding
+	const barWidth = 16
+
+	var lines []string
+	lines = append(lines, ui.PadLine(" "+ui.Title.Render("THIS WEEK")+"  "+staleMarker(m.CalendarStaleSince), inner))
+	lines = append(lines, ui.PadLine(" "+ui.Label.Render("done ")+ui.Online.Render("█")+ui.Label.Render("  outstanding ")+ui.Offline.Render("█"), inner))
+	lines = append(lines, "")
+
+	days := m.weeklyBurnDown(m.LastUpdate)
+	total := 0
+	if len(days) > 0 {
+		total = days[0].Completed + days[0].Outstanding
+	}
+	for _, d := range days {
+		dayLabel := ui.Label.Render(d.Day.Format("Mon"))
+		if d.Future {
+			lines = append(lines, ui.PadLine(" "+dayLabel+" "+ui.Dim.Render(strings.Repeat("░", barWidth)), inner))
+			continue
+		}
+		done, left := 0, 0
+		if total > 0 {
+			done = d.Completed * barWidth / total
+			left = barWidth - done
+		}
+		bar := ui.Online.Render(strings.Repeat("█", done)) +
+			ui.Offline.Render(strings.Repeat("█", left)) +
+			ui.Dim.Render(strings.Repeat("░", barWidth-done-left))
+		count := ui.Value.Render(fmt.Sprintf("%d/%d", d.Completed, total))
+		lines = append(lines, ui.PadLine(" "+dayLabel+" "+bar+" "+count, inner))
+	}
+
+	if total == 0 {
+		lines = append(lines, "")
+		lines = append(lines, ui.PadLine(" "+ui.Label.Render("No deadlines due this week"), inner))
+	}
+
+	content := strings.Join(lines, "\n")
+	return ui.NewBox(width).WithLeftPadding(1).Render(content)
+}
+
+// deadlineCountdownStyle picks the color for a not-done deadline countdown:
+// red when overdue, yellow under a day.
+func (m Model) deadlineCountdownStyle(e types.Event) lipgloss.Style {
+	left := e.Date.Sub(m.LastUpdate)
+	switch {
+	case left < 0:
+		return ui.Offline
+	case left < 24*time.Hour:
+		return ui.Warning
+	default:
+		return ui.Label
+	}
+}
@@ -0,0 +1,254 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"monoview/internal/ui"
+)
+
+// Form widgets shared by the add-event, timer and alarm forms. A form is a
+// slice of formField pointing at the Model's string fields; typing edits the
+// focused value, arrow keys drive the date picker / time spinner / duration
+// presets, and each field validates itself for inline error text.
+
+type fieldKind int
+
+const (
+	fieldText     fieldKind = iota
+	fieldDate               // YYYY-MM-DD; ←/→ day, ↑/↓ week
+	fieldTime               // HH:MM or HH:MM:SS; ↑/↓ 5 minutes, ←/→ hour
+	fieldClock              // HH:MM only (ACHTUNG alarms are set to the minute); keys as fieldTime
+	fieldDuration           // 5m, 1h30m or seconds; ↑/↓ cycle durationPresets
+)
+
+// durationPresets are cycled with ↑/↓ in duration fields.
+var durationPresets = []string{"1m", "5m", "10m", "15m", "25m", "30m", "45m", "1h", "2h"}
+
+type formField struct {
+	Label    string
+	Value    *string
+	Kind     fieldKind
+	Optional bool
+	// Check is an optional extra validation run after the kind's format check
+	// (e.g. alarm time must be in the future). Returns "" when valid.
+	Check func(string) string
+}
+
+// validate returns the error text for the field, or "" when valid.
+func (f formField) validate() string {
+	v := strings.TrimSpace(*f.Value)
+	if v == "" {
+		if f.Optional {
+			return ""
+		}
+		return "required"
+	}
+	switch f.Kind {
+	case fieldDate:
+		if _, err := time.Parse("2006-01-02", v); err != nil {
+			return "expected YYYY-MM-DD"
+		}
+	case fieldTime:
+		if _, ok := parseFormTime(v); !ok {
+			return "expected HH:MM"
+		}
+	case fieldClock:
+		if _, err := time.Parse("15:04", v); err != nil {
+			return "expected HH:MM"
+		}
+	case fieldDuration:
+		if parseDuration(v) < 0 {
+			return "expected duration like 5m, 1h30m or seconds"
+		}
+	}
+	if f.Check != nil {
+		return f.Check(v)
+	}
+	return ""
+}
+
+// firstInvalidField returns the index of the first invalid field, or -1.
+func firstInvalidField(fields []formField) int {
+	for i, f := range fields {
+		if f.validate() != "" {
+			return i
+		}
+	}
+	return -1
+}
+
+// rejectedField is firstInvalidField for a submit: it also logs why the form was refused.
+func rejectedField(fields []formField) int {
+	bad := firstInvalidField(fields)
+	if bad >= 0 {
+		f := fields[bad]
+		logger.Info("form rejected", "field", f.Label, "value", *f.Value, "reason", f.validate())
+	}
+	return bad
+}
+
+func parseFormTime(s string) (time.Time, bool) {
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// handleKey edits the field for a key press. Returns true if the key was consumed.
+func (f formField) handleKey(msg tea.KeyMsg) bool {
+	key := msg.String()
+	switch key {
+	case "backspace":
+		runes := []rune(*f.Value)
+		if len(runes) > 0 {
+			*f.Value = string(runes[:len(runes)-1])
+		}
+		return true
+	case " ":
+		*f.Value += " "
+		return true
+	case "up", "down", "left", "right":
+		switch f.Kind {
+		case fieldDate:
+			days := map[string]int{"left": -1, "right": 1, "up": -7, "down": 7}[key]
+			*f.Value = stepDate(*f.Value, days)
+		case fieldTime, fieldClock:
+			mins := map[string]int{"up": 5, "down": -5, "left": -60, "right": 60}[key]
+			*f.Value = stepTime(*f.Value, mins)
+		case fieldDuration:
+			switch key {
+			case "up":
+				*f.Value = stepDurationPreset(*f.Value, 1)
+			case "down":
+				*f.Value = stepDurationPreset(*f.Value, -1)
+			}
+		}
+		return true
+	}
+	if msg.Type == tea.KeyRunes && len(msg.Runes) > 0 {
+		*f.Value += string(msg.Runes)
+		return true
+	}
+	return false
+}
+
+// stepDate moves a YYYY-MM-DD value by days; an empty or invalid value starts from today.
+func stepDate(v string, days int) string {
+	t, err := time.Parse("2006-01-02", strings.TrimSpace(v))
+	if err != nil {
+		return time.Now().Format("2006-01-02")
+	}
+	return t.AddDate(0, 0, days).Format("2006-01-02")
+}
+
+// stepTime moves an HH:MM value by mins, wrapping around midnight; an empty or
+// invalid value starts from now.
+func stepTime(v string, mins int) string {
+	t, ok := parseFormTime(strings.TrimSpace(v))
+	if !ok {
+		return time.Now().Format("15:04")
+	}
+	total := t.Hour()*60 + t.Minute() + mins
+	total = (total%(24*60) + 24*60) % (24 * 60)
+	return fmt.Sprintf("%02d:%02d", total/60, total%60)
+}
+
+// stepDurationPreset moves to the next/previous preset relative to the current value.
+func stepDurationPreset(v string, dir int) string {
+	cur := parseDuration(strings.TrimSpace(v))
+	if dir > 0 {
+		for _, p := range durationPresets {
+			if parseDuration(p) > cur {
+				return p
+			}
+		}
+		return durationPresets[len(durationPresets)-1]
+	}
+	for i := len(durationPresets) - 1; i >= 0; i-- {
+		if cur < 0 || parseDuration(durationPresets[i]) < cur {
+			return durationPresets[i]
+		}
+	}
+	return durationPresets[0]
+}
+
+// renderFormFields draws label/value lines with a cursor on the focused field
+// and inline error text under each invalid field.
+func renderFormFields(fields []formField, focus int) []string {
+	var lines []string
+	for i, f := range fields {
+		line := ui.Label.Render("  "+f.Label+": ") + ui.Value.Render(*f.Value)
+		if i == focus {
+			line += ui.Dim.Render("▌")
+			if hint := fieldHint(f.Kind); hint != "" {
+				line += "  " + ui.Help.Render(hint)
+			}
+		}
+		lines = append(lines, line)
+		if msg := f.validate(); msg != "" {
+			style := ui.Offline
+			if strings.TrimSpace(*f.Value) == "" {
+				style = ui.Warning
+			}
+			lines = append(lines, "    "+style.Render("✗ "+msg))
+		}
+	}
+	return lines
+}
+
+func fieldHint(kind fieldKind) string {
+	switch kind {
+	case fieldDate:
+		return "←/→ day ↑/↓ week"
+	case fieldTime, fieldClock:
+		return "↑/↓ 5m ←/→ 1h"
+	case fieldDuration:
+		return "↑/↓ presets"
+	}
+	return ""
+}
+
+// renderFormActions renders the key hints; [Enter] submit is struck out while the form is invalid.
+func renderFormActions(fields []formField) string {
+	submit := ui.Online.Render("[Enter] submit")
+	if firstInvalidField(fields) >= 0 {
+		submit = ui.Dim.Strikethrough(true).Render("[Enter] submit")
+	}
+	return ui.Dim.Render("  [Tab] next  ") + submit + ui.Dim.Render("  [Esc] cancel")
+}
+
+// handleFormKeys implements Tab/Shift+Tab/Enter navigation plus field editing for a form.
+// submit is called when Enter is pressed on the last field and the form is valid;
+// if it is invalid, focus jumps to the first invalid field instead.
+func handleFormKeys(msg tea.KeyMsg, fields []formField, focus *int, submit func()) bool {
+	n := len(fields)
+	switch msg.String() {
+	case "tab":
+		*focus = (*focus + 1) % n
+		return true
+	case "shift+tab":
+		*focus = (*focus + n - 1) % n
+		return true
+	case "enter":
+		if *focus == n-1 {
+			if bad := rejectedField(fields); bad >= 0 {
+				*focus = bad
+				return true
+			}
+			submit()
+			return true
+		}
+		*focus = (*focus + 1) % n
+		return true
+	}
+	if *focus < 0 || *focus >= n {
+		*focus = 0
+	}
+	return fields[*focus].handleKey(msg)
+}
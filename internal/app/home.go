@@ -16,7 +16,12 @@ func (m Model) renderHome(showAchtungFormInline bool) string {
 
 	vertexContent := padToLinesWithSpacing(m.renderVertexDevicesContent(), uniformHeight)
 	ukazContent := padToLinesWithSpacing(m.renderUkazDevicesContent(), uniformHeight)
-	achtungContent := padToLinesWithSpacing(m.renderAchtungContent(showAchtungFormInline), uniformHeight)
+	achtungRaw := m.renderAchtungContent(showAchtungFormInline)
+	achtungHeight := uniformHeight
+	if n := strings.Count(achtungRaw, "\n") + 3; n > achtungHeight {
+		achtungHeight = n // grow with the job list instead of cutting it off
+	}
+	achtungContent := padToLinesWithSpacing(achtungRaw, achtungHeight)
 
 	focusVertex := !m.HomeFocusAchtung && !m.HomeFocusUkaz
 	focusUkaz := !m.HomeFocusAchtung && m.HomeFocusUkaz
@@ -29,7 +34,12 @@ func (m Model) renderHome(showAchtungFormInline bool) string {
 	ukazSection := ukazBox.Render(ukazContent)
 	achtungSection := achtungBox.Render(achtungContent)
 
-	content := lipgloss.JoinVertical(lipgloss.Left, vertexSection, "", ukazSection, "", achtungSection)
+	sections := []string{vertexSection, "", ukazSection, "", achtungSection}
+	if fired := m.renderFiredTodayContent(); fired != "" {
+		firedBox := ui.NewBox(boxWidth).WithTitle(fmt.Sprintf("FIRED  today (%d)", len(m.firedToday()))).WithDimTitle(true)
+		sections = append(sections, "", firedBox.Render(fired))
+	}
+	content := lipgloss.JoinVertical(lipgloss.Left, sections...)
 	return ui.IndentLines(content, "  ")
 }
 
@@ -85,36 +95,128 @@ func (m Model) renderDevicesForNode(node string) string {
 }
 
 func (m Model) renderAchtungContent(showFormInline bool) string {
-	if len(m.AchtungJobs) == 0 {
-		return ui.Dim.Render("  No timers or alarms.\n  [t] New timer  [a] New alarm")
+	if len(m.AchtungJobs) == 0 && len(m.RecurringAlarms) == 0 && !m.Pomodoro.Active {
+		return ui.Dim.Render("  No timers or alarms.\n  [t] New timer  [a] New alarm  [w] pomodoro")
 	}
+	lines := m.achtungLines()
+	lines = append(lines, "")
+	lines = append(lines, ui.Dim.Render("  [t] timer  [a] alarm  [d] delete  [f] full screen"))
+	lines = append(lines, ui.Dim.Render("  [p] pause  [e] +5m  [w] pomodoro"))
+	return strings.Join(lines, "\n")
+}
+
+// achtungLines lists the pomodoro, jobs, unarmed alarms and the alarm timeline, without key hints.
+func (m Model) achtungLines() []string {
 	var lines []string
+	if marker := staleMarker(m.AchtungStaleSince); marker != "" {
+		lines = append(lines, "  "+marker)
+	}
+	if line := m.pomodoroStatusLine(); line != "" {
+		lines = append(lines, line)
+	}
 	for i, j := range m.AchtungJobs {
-		active := i == m.SelectedAchtungJob
-		kindStyle := ui.Label
-		if j.Kind == "ALARM" {
-			kindStyle = ui.Accent
-		}
-		line := fmt.Sprintf("%s %s  %s  %s",
-			kindStyle.Render(j.Kind+":"),
-			ui.Value.Render(j.Name),
-			ui.Label.Render("left:"),
-			ui.Value.Render(j.Remaining))
-		if j.Due != "" && j.Due != "—" {
-			line += "  " + ui.Label.Render("due:") + " " + ui.Value.Render(j.Due)
-		}
-		if active {
+		line := m.renderAchtungJobLine(j)
+		if i == m.SelectedAchtungJob {
 			line = "▌ " + line
 		} else {
 			line = "  " + line
 		}
 		lines = append(lines, line)
 	}
-	lines = append(lines, "")
-	lines = append(lines, ui.Dim.Render("  [t] timer  [a] alarm  [d] delete"))
+	lines = append(lines, m.recurringNotArmedLines()...)
+	if timeline := m.renderAlarmTimeline(); timeline != "" {
+		lines = append(lines, timeline)
+	}
+	return lines
+}
+
+// maxFiredTodayLines is how many of today's fires the Home sheet lists.
+const maxFiredTodayLines = 3
+
+// renderFiredTodayContent lists today's fires (newest first) with their outcome; "" when none.
+func (m Model) renderFiredTodayContent() string {
+	fired := m.firedToday()
+	if len(fired) == 0 {
+		return ""
+	}
+	var lines []string
+	for i, a := range fired {
+		if i == maxFiredTodayLines {
+			lines = append(lines, ui.Dim.Render(fmt.Sprintf("  … %d more", len(fired)-i)))
+			break
+		}
+		outcome := ui.Warning.Render("pending")
+		switch a.Outcome {
+		case "dismissed":
+			outcome = ui.Dim.Render("dismissed")
+		case "snoozed":
+			outcome = ui.Accent.Render("snoozed")
+		case "missed":
+			outcome = ui.Offline.Render("missed")
+		}
+		lines = append(lines, fmt.Sprintf("  %s  %s %s  %s",
+			ui.Label.Render(a.FiredAt.Format("15:04")),
+			ui.Label.Render(fmt.Sprintf("%-5s", a.JobKind)),
+			ui.Value.Render(fmt.Sprintf("%-20s", ui.TruncateString(a.JobName, 20))),
+			outcome))
+	}
 	return strings.Join(lines, "\n")
 }
 
+// pomodoroStatusLine shows the running phase and cycle plus today's finished sessions.
+func (m Model) pomodoroStatusLine() string {
+	today := m.pomodoroSessionsToday()
+	if !m.Pomodoro.Active {
+		if today == 0 {
+			return ""
+		}
+		return ui.Dim.Render(fmt.Sprintf("  POMODORO: off  %d today", today))
+	}
+	phaseStyle := ui.Online
+	if m.Pomodoro.Phase != "work" {
+		phaseStyle = ui.Accent
+	}
+	every := m.PomodoroPlan.LongEvery
+	cycle := m.Pomodoro.Cycle
+	if every > 0 {
+		cycle = (cycle-1)%every + 1
+	}
+	return fmt.Sprintf("  %s %s  %s  %s",
+		ui.Label.Render("POMODORO:"),
+		phaseStyle.Render(pomodoroPhaseLabel(m.Pomodoro.Phase)),
+		ui.Value.Render(fmt.Sprintf("cycle %d/%d", cycle, every)),
+		ui.Dim.Render(fmt.Sprintf("%d today", today)))
+}
+
+// recurringNotArmedLines lists recurring alarms that ACHTUNG does not hold right now
+// (e.g. queued while offline), with their next occurrence.
+func (m Model) recurringNotArmedLines() []string {
+	var lines []string
+	for _, r := range m.RecurringAlarms {
+		armed := false
+		for _, j := range m.AchtungJobs {
+			if j.Name == r.Name {
+				armed = true
+				break
+			}
+		}
+		if armed {
+			continue
+		}
+		next := "—"
+		if !r.NextAt.IsZero() {
+			next = r.NextAt.Format("Mon 02 Jan 15:04")
+		}
+		lines = append(lines, fmt.Sprintf("  %s %s  %s  %s %s",
+			ui.Accent.Render("ALARM:"),
+			ui.Value.Render(r.Name),
+			ui.Accent.Render("↻ "+repeatLabel(r.Days)),
+			ui.Label.Render("next:"),
+			ui.Value.Render(next)))
+	}
+	return lines
+}
+
 func (m Model) deviceNodes() []string {
 	seen := map[string]bool{}
 	var order []string
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"monoview/internal/creds"
+	"monoview/internal/ui"
+)
+
+// renderAllHubs lists the nodes of every hub, grouped under a line per hub
+// with its connection state ([a] on the System sheet).
+func (m Model) renderAllHubs() string {
+	header := ui.Title.Render("▌ALL HUBS") + " " + ui.Dim.Render("[↑/k ↓/j] select  [Enter] show on its hub  [a/Esc] back  [@] next hub") + "\n\n"
+	cols := fmt.Sprintf("    %-12s %-10s %7s  %-12s %s", "NODE", "STATUS", "RTT", "UPTIME", "24h")
+	lines := []string{ui.Label.Render(cols)}
+
+	states := m.hubStates()
+	row := 0
+	for i, h := range states {
+		state := ui.Offline.Render("● offline")
+		if h.Hub != nil && h.Hub.Connected() {
+			state = ui.Online.Render("● online")
+		}
+		title := ui.Title.Render(strings.ToUpper(h.Name))
+		if i == m.index {
+			title += ui.Dim.Render(" (shown)")
+		}
+		extra := ""
+		if len(h.Outbox) > 0 {
+			extra = "  " + ui.Warning.Render(fmt.Sprintf("Q%d", len(h.Outbox)))
+		}
+		if c := renderCertExpiry(h.Cert, m.LastUpdate); c != "" {
+			extra += "  " + c
+		}
+		lines = append(lines, "", title+"  "+state+extra)
+		if len(h.Nodes) == 0 {
+			lines = append(lines, ui.Dim.Render("    no nodes yet"))
+		}
+		for _, n := range h.Nodes {
+			prefix := "    "
+			if row == m.SelectedHubNode {
+				prefix = "  ▌ "
+			}
+			rtt := "—"
+			if (n.Status == "online" || n.Status == "degraded") && n.PingMs > 0 {
+				rtt = fmt.Sprintf("%dms", n.PingMs)
+			}
+			status := fmt.Sprintf("%-10s", n.Status)
+			switch n.Status {
+			case "online":
+				status = ui.Online.Render(status)
+			case "degraded":
+				status = ui.Warning.Render(status)
+			case "offline":
+				status = ui.Offline.Render(status)
+			default:
+				status = ui.Dim.Render(status)
+			}
+			line := fmt.Sprintf("%s%s %s %7s  %-12s %s",
+				prefix,
+				ui.Value.Render(fmt.Sprintf("%-12s", ui.TruncateString(n.Name, 12))),
+				status,
+				rtt,
+				ui.TruncateString(n.Uptime, 12),
+				renderAvailability(h.NodeHistory[n.Name], m.LastUpdate, 24*time.Hour))
+			lines = append(lines, line)
+			row++
+		}
+	}
+	return ui.IndentLines(header+strings.Join(lines, "\n"), "  ")
+}
+
+// renderCertExpiry shows how long a hub's client certificate is still valid:
+// yellow under certWarnDays, red in the last week and once expired. Empty
+// without mTLS.
+func renderCertExpiry(c creds.Info, now time.Time) string {
+	if c.NotAfter.IsZero() {
+		return ""
+	}
+	days := c.DaysLeft(now)
+	switch {
+	case days < 0:
+		return ui.Offline.Render(fmt.Sprintf("client cert expired %s", c.NotAfter.Format("2006-01-02")))
+	case days < 7:
+		return ui.Offline.Render(fmt.Sprintf("client cert expires in %dd", days))
+	case days < certWarnDays:
+		return ui.Warning.Render(fmt.Sprintf("client cert expires in %dd", days))
+	}
+	return ui.Dim.Render(fmt.Sprintf("client cert valid %dd", days))
+}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+)
+
+// logger gets monoview's own decisions for the log file: connection
+// changes, commands sent and queued, dropped payloads, rejected forms and
+// everything shown in the System log pane. Discarded until SetLogger.
+var logger = slog.New(slog.DiscardHandler)
+
+// SetLogger sets the logger of the app package.
+func SetLogger(l *slog.Logger) {
+	logger = l
+}
+
+// log writes to the logger with the current hub attached when there are several.
+func (m *Model) log(level slog.Level, msg string, args ...any) {
+	if hub := m.hubLabel(); hub != "" {
+		args = append(args, "hub", hub)
+	}
+	logger.Log(context.Background(), level, msg, args...)
+}
+
+// paneLevel maps a log pane level (INFO, WARN, ERR, MSG) to a slog level.
+// Hub traffic (MSG) is only logged at debug.
+func paneLevel(level string) slog.Level {
+	switch level {
+	case "WARN":
+		return slog.LevelWarn
+	case "ERR":
+		return slog.LevelError
+	case "MSG":
+		return slog.LevelDebug
+	}
+	return slog.LevelInfo
+}
@@ -1,24 +1,33 @@
 package app
 
 import (
+	"log/slog"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/MrZloHex/monolink"
+	"monoview/internal/config"
+	"monoview/internal/creds"
+	"monoview/internal/diag"
+	"monoview/internal/notify"
+	"monoview/internal/props"
 	"monoview/internal/types"
 )
 
 const (
-	pingInterval     = 2 * time.Minute
 	achtungSyncEvery = 1 * time.Minute
 
 	tickIntervalFast = 1 * time.Second  // when ACHTUNG countdowns need per-second updates
 	tickIntervalIdle = 15 * time.Second // when idle: fewer wakeups, less CPU/redraws
 )
 
-// HubMsg wraps a concentrator message arriving through the inbox channel.
-type HubMsg monolink.Message
+// HubMsg wraps a concentrator message arriving through a hub's inbox channel.
+type HubMsg struct {
+	Hub string // HubState.Name of the hub it came in on
+	Msg monolink.Message
+}
 
 // Model is the main application model
 type Model struct {
@@ -27,8 +36,17 @@ type Model struct {
 	Height      int
 	LastUpdate  time.Time
 
-	// Concentrator client (runs in background goroutine)
-	Hub *monolink.Client
+	// Concentrators: the active hub's state is embedded, the others wait in
+	// Hubs (see model_hubs.go). Hubs[i] is stale while hub i is active.
+	HubState
+	Hubs            []HubState
+	SystemAllHubs   bool // System sheet lists the nodes of every hub ([a])
+	SelectedHubNode int
+	SystemDiag      bool // System sheet shows the connection diagnostics ([c])
+
+	// Settings from the config file (defaults when there is none)
+	Config   config.Config
+	Notifier *notify.Dispatcher // routes fire/offline/deadline events; nil = none
 
 	// Calendar
 	SelectedDate        time.Time
@@ -38,6 +56,13 @@ type Model struct {
 	Deadlines           []types.Event // from GET:DEADLINES (upcoming deadlines box)
 	Schedule            []types.ScheduleEntry
 
+	// Deadlines sheet (done state is local, persisted by deadline ID)
+	DeadlineDone     map[string]time.Time // deadline ID -> when it was marked done
+	SelectedDeadline int
+	DeadlineSort     int               // index into deadlineSortNames
+	DeadlineFilter   int               // index into deadlineFilterNames
+	deadlineNotified map[string]string // deadline ID -> day it was last notified (YYYY-MM-DD)
+
 	// Diary
 	DiaryEntries  []types.DiaryEntry
 	SelectedEntry int
@@ -46,19 +71,21 @@ type Model struct {
 	HomeDevices    []types.HomeDevice
 	SelectedDevice int
 
-	// System
-	Nodes               []types.SystemNode
-	Logs                []types.LogEntry
+	// System (nodes, logs and history are per hub, in HubState)
 	SelectedNode        int
 	SystemFocusLogs     bool   // true = j/k scroll logs; Tab toggles
 	LogScrollOffset     int    // 0 = newest at top; scroll up (k) increases to see older
 	SystemCommandInput  bool   // true = typing custom message to bus (:)
 	SystemCommandBuffer string // TO:VERB:NOUN[:args...]
+	NodeDetailView      bool   // [v] selected node's health history in right panel
+	NodeInspect         bool   // [i] node view: topics, traffic, quick actions
+	SelectedNodeAction  int    // index into the node's devices in the node view
 
 	// ACHTUNG (timers & alarms, shown on Home sheet)
 	AchtungJobs            []types.AchtungJob
 	SelectedAchtungJob     int
 	AchtungTimerMenu       bool   // true = adding timer (all fields in right panel)
+	AchtungTimerPicking    bool   // timer form shows the preset list first
 	AchtungTimerDuration   string // e.g. "5m"
 	AchtungTimerName       string // optional, Enter for auto
 	AchtungTimerFocusField int    // 0=duration, 1=name
@@ -66,14 +93,40 @@ type Model struct {
 	AchtungAlarmDate       string // YYYY-MM-DD
 	AchtungAlarmTime       string // HH:MM
 	AchtungAlarmName       string // optional
-	AchtungAlarmFocusField int    // 0=date, 1=time, 2=name
+	AchtungAlarmRepeat     string // once, daily, weekdays, weekends, mon,wed,...
+	AchtungAlarmSkipHoli   string // y/n: recurring alarm skips GOVERNOR holidays
+	AchtungAlarmFocusField int    // 0=date, 1=time, 2=name, 3=repeat, 4=skip holidays
 	HomeFocusAchtung       bool   // on Home: true = focus ACHTUNG panel (j/k, enter, t, a, d)
 	HomeFocusUkaz          bool   // on Home: when false and !Achtung = VERTEX; when true = UKAZ
 	AchtungViewMenu        bool   // Enter on job shows details in right panel
 	LastAchtungSync        time.Time
-
-	// Fire alert popup (ALL:FIRE:TIMER/ALARM from ACHTUNG)
-	FireAlert types.FireAlert
+	RecurringAlarms        []types.RecurringAlarm // repeat definitions; ACHTUNG holds the next occurrence
+	RecentTimers           []config.TimerPreset   // last custom timers for the preset picker, newest first
+
+	// Pomodoro mode ([w] in ACHTUNG): chained work/break timers
+	Pomodoro         types.Pomodoro
+	PomodoroPlan     types.PomodoroPlan
+	PomodoroSessions map[string]int // completed work sessions per day (YYYY-MM-DD)
+	pomodoroBright   int            // LED brightness to restore after a dimmed break; -1 = none
+
+	achtungTotals  map[string]time.Duration // lengths of timers created here, until listed
+	AchtungBigView bool                     // [f] full-screen countdown of the selected job
+
+	// Fire alert stack (ALL:FIRE:TIMER/ALARM from ACHTUNG)
+	FireAlerts        []types.FireAlert // pending, oldest first
+	SelectedFireAlert int
+	FiredHistory      []types.FireAlert // today's fires with outcome, oldest first
+	SnoozeFor         time.Duration     // [s] on the popup re-arms the job this far ahead (default 5m)
+
+	// Properties sheet: browser over Props (node → topic → property)
+	PropsCollapsed map[string]bool // "NODE" or "NODE.TOPIC" rows folded with [←]
+	SelectedProp   int
+	PropEditing    bool   // typing a new value for the selected property
+	PropEditBuffer string // value sent with SET on Enter
+
+	// Kiosk mode (--kiosk): read-only keys, sheet rotation, night dimming
+	Kiosk         types.Kiosk
+	KioskSwitched time.Time // when the current sheet was shown
 
 	// Calendar: viewing selected event details in right panel (Enter on event)
 	EventViewMenu bool
@@ -88,9 +141,65 @@ type Model struct {
 	EventAddNotes       string
 	EventAddVisibleFrom string // optional YYYY-MM-DD; omit = default (7 days before deadline)
 
+	// Quick add prompt ([+]): free text parsed into an event, timer or alarm
+	QuickAddInput  bool
+	QuickAddBuffer string
+
+	// Offline cache: non-zero = data shown came from the cache saved at that time
+	CalendarStaleSince time.Time
+	AchtungStaleSince  time.Time
+	stateCacheDirty    bool
+
+	// Outbox review ([o]); the queue itself is per hub
+	OutboxView     bool
+	SelectedOutbox int
+
+	// Node alerts raised by the config's alert rules ([!] to review and acknowledge)
+	Alerts        []types.NodeAlert
+	AlertsView    bool
+	SelectedAlert int
+}
+
+// HubState is everything that belongs to one concentrator connection.
+type HubState struct {
+	Name string           // profile name from the config ("" with a single unnamed hub)
+	Hub  *monolink.Client // nil when the hub could not be reached at startup
+
+	index int    // position in Model.Hubs
+	dir   string // state subdirectory: "" for the first hub, hubs/<name> for the others
+
+	Nodes            []types.SystemNode
+	Logs             []types.LogEntry
+	NodeHistory      map[string]*types.NodeHistory
+	nodeHistoryDirty bool
+	KnownNodes       map[string]*types.KnownNode // discovered nodes: ping noun, pinned, hidden
+	Props            *props.Store                // every OK:<TOPIC>[:<PROP>]:<VALUE> reply, by node
+	NodeTraffic      map[string][]types.NodeMessage
+	ProtoErrors      []types.ProtocolError // rejected payloads, newest last
+	ProtoErrorCount  map[string]int        // rejected payloads by node since start
+
+	// Outbox: commands queued while offline, replayed on reconnect
+	Outbox          []types.OutboxEntry
+	hubWasConnected bool
+	hubChecked      bool
+	// A replay waits for the fresh ACHTUNG list and GOVERNOR events until
+	// replayBy (zero when none is pending), see startReplay.
+	replayBy                         time.Time
+	replayWaitJobs, replayWaitEvents bool
+
 	// Traffic indicators (timestamps of last rx/tx for arrow display)
 	LastRx time.Time
 	LastTx time.Time
+
+	Cert creds.Info // client certificate for mTLS; zero without one
+
+	// Connection diagnostics (model_connection.go)
+	Dial        HubDial
+	LastErr     string // latest connection error, with when it happened
+	LastErrAt   time.Time
+	Diag        *diag.Report // last probe; nil before the first
+	diagRunning bool
+	dialing     bool
 }
 
 // TickMsg is sent periodically (interval varies: fast when ACHTUNG countdowns, idle otherwise)
@@ -107,7 +216,8 @@ func (m *Model) needsFastTick() bool {
 }
 
 func nextTickInterval(m *Model) time.Duration {
-	if m.needsFastTick() {
+	// The kiosk clock and sheet rotation need the fast tick too.
+	if m.needsFastTick() || m.Kiosk.Enabled {
 		return tickIntervalFast
 	}
 	return tickIntervalIdle
@@ -130,7 +240,7 @@ func (m *Model) scheduleNextCmds() tea.Cmd {
 // NewModel creates the initial model with sample data
 func NewModel() Model {
 	now := time.Now()
-	return Model{
+	m := Model{
 		ActiveSheet:  types.SheetCalendar,
 		LastUpdate:   now,
 		SelectedDate: now,
@@ -139,6 +249,16 @@ func NewModel() Model {
 		Events:   nil,
 		Schedule: nil,
 
+		Config:           config.Default(),
+		DeadlineDone:     loadDeadlineDone(),
+		RecentTimers:     loadRecentTimers(),
+		RecurringAlarms:  loadRecurringAlarms(),
+		PomodoroPlan:     DefaultPomodoroPlan(),
+		PomodoroSessions: loadPomodoroSessions(),
+		pomodoroBright:   -1,
+		FiredHistory:     loadFiredHistory(),
+		Hubs:             make([]HubState, 1),
+
 		DiaryEntries: []types.DiaryEntry{
 			{Date: now, Content: "Started working on MonoView TUI...", Mood: "focused"},
 			{Date: now.Add(-24 * time.Hour), Content: "Fixed the WebSocket connection issues.", Mood: "productive"},
@@ -176,40 +296,78 @@ func NewModel() Model {
 		},
 		SelectedDevice: 0,
 
-		Nodes: []types.SystemNode{
-			{Name: "VERTEX", PingNoun: "PINT", Status: "offline", Uptime: "—"},
-			{Name: "ACHTUNG", PingNoun: "PING", Status: "offline", Uptime: "—"},
-			{Name: "GOVERNOR", PingNoun: "PING", Status: "offline", Uptime: "—"},
-			{Name: "UKAZ", PingNoun: "PING", Status: "offline", Uptime: "—"},
+		HubState: HubState{
+			NodeHistory: loadNodeHistory(""),
+			KnownNodes:  loadKnownNodes(""),
+			Props:       props.New(),
+			Nodes: []types.SystemNode{
+				{Name: "VERTEX", PingNoun: "PINT", Status: "offline", Uptime: "—", Since: now},
+				{Name: "ACHTUNG", PingNoun: "PING", Status: "offline", Uptime: "—", Since: now},
+				{Name: "GOVERNOR", PingNoun: "PING", Status: "offline", Uptime: "—", Since: now},
+				{Name: "UKAZ", PingNoun: "PING", Status: "offline", Uptime: "—", Since: now},
+			},
 		},
 		SelectedNode: 0,
 	}
+	m.addPinnedNodes()
+	m.loadStateCache()
+	return m
 }
 
 func (m Model) Init() tea.Cmd {
-	var cmds []tea.Cmd
-	if m.Hub != nil {
-		m.queryDeviceStates()
-		m.requestGovernorSchedule()
-		m.requestGovernorEvents()
-		m.requestGovernorDeadlines()
-	}
-	return tea.Batch(append(cmds, (&m).scheduleNextCmds())...)
+	m.eachHub(func() {
+		if m.Hub == nil {
+			return
+		}
+		// The home nodes (VERTEX, GOVERNOR, …) are on the first hub.
+		if m.index == 0 {
+			m.queryDeviceStates()
+			m.requestGovernorSchedule()
+			m.requestGovernorEvents()
+			m.requestGovernorDeadlines()
+		}
+		m.requestNodeList()
+	})
+	return (&m).scheduleNextCmds()
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		// While typing command, only command handler gets keys (disables all hotkeys)
-		if m.SystemCommandInput {
+		// In kiosk mode only sheet keys, fire alert keys and Ctrl+C do anything.
+		if m.Kiosk.Enabled {
+			if m.handleKioskKeys(msg) {
+				return m, nil
+			}
+		} else if len(m.FireAlerts) > 0 {
+			// The fire alert popup is drawn over every other view and input.
+			if m.handleFireAlertKeys(msg) {
+				return m, nil
+			}
+		} else if m.SystemCommandInput {
+			// While typing command, only command handler gets keys (disables all hotkeys)
 			if m.handleSystemCommandKeys(msg) {
 				return m, nil
 			}
 			// handleSystemCommandKeys returned false: allow q/ctrl+c to fall through to quit
-		} else if m.FireAlert.Show {
-			switch msg.String() {
-			case "enter", " ", "q", "esc":
-				m.dismissFireAlert()
+		} else if m.QuickAddInput {
+			if m.handleQuickAddKeys(msg) {
+				return m, nil
+			}
+		} else if m.OutboxView {
+			if m.handleOutboxKeys(msg) {
+				return m, nil
+			}
+		} else if m.PropEditing {
+			if m.handlePropEditKeys(msg) {
+				return m, nil
+			}
+		} else if m.AlertsView {
+			if m.handleAlertKeys(msg) {
+				return m, nil
+			}
+		} else if m.AchtungBigView {
+			if m.handleAchtungBigViewKeys(msg) {
 				return m, nil
 			}
 		} else {
@@ -225,36 +383,56 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.handleSystemCommandKeys(msg) {
 				return m, nil
 			}
+			if m.handleDeadlineKeys(msg.String()) {
+				return m, nil
+			}
+			if m.handlePropsKeys(msg.String()) {
+				return m, nil
+			}
+			if m.handleDashboardKeys(msg.String()) {
+				return m, nil
+			}
+			if ok, cmd := m.handleDiagKeys(msg.String()); ok {
+				return m, cmd
+			}
+			if m.handleHubKeys(msg.String()) {
+				return m, nil
+			}
+			if m.handleNodeInspectKeys(msg.String()) {
+				return m, nil
+			}
+			if m.handleNodeDetailKeys(msg.String()) {
+				return m, nil
+			}
+			if m.handleNodeDiscoveryKeys(msg.String()) {
+				return m, nil
+			}
 		}
 		switch msg.String() {
 		case "q", "ctrl+c":
+			m.saveStateCache()
+			m.eachHub(m.saveNodeHistory)
 			return m, tea.Quit
 
 		// Sheet navigation
-		case "1":
-			m.ActiveSheet = types.SheetCalendar
-			m.CalendarFocusEvents = false
-			m.EventViewMenu = false
-			m.SystemCommandInput = false
-			if m.EventAddMenu {
-				m.eventAddReset()
-			}
-		case "2":
-			m.ActiveSheet = types.SheetDiary
-			m.SystemCommandInput = false
-		case "3":
-			m.ActiveSheet = types.SheetHome
-			m.SystemCommandInput = false
-			m.requestAchtungList()
-		case "4":
-			m.ActiveSheet = types.SheetSystem
-			m.SystemFocusLogs = false
-			m.SystemCommandInput = false
+		case "1", "2", "3", "4", "5", "6", "7":
+			m.showSheet(types.Sheet(msg.String()[0] - '1'))
 		case ":":
 			if m.ActiveSheet == types.SheetSystem && m.Hub != nil && !m.SystemCommandInput {
 				m.SystemCommandInput = true
 				m.SystemCommandBuffer = ""
 			}
+		case "+":
+			if !m.EventAddMenu {
+				m.QuickAddInput = true
+				m.QuickAddBuffer = ""
+			}
+		case "o":
+			m.OutboxView = true
+			m.SelectedOutbox = 0
+		case "!":
+			m.AlertsView = true
+			m.SelectedAlert = 0
 		case "tab":
 			if m.ActiveSheet == types.SheetHome {
 				m.homeFocusNext()
@@ -283,7 +461,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Calendar: [a] or [n] add new event (opens form)
 		case "a", "n":
-			if m.ActiveSheet == types.SheetCalendar && !m.EventAddMenu && m.Hub != nil {
+			if m.ActiveSheet == types.SheetCalendar && !m.EventAddMenu {
 				m.EventAddMenu = true
 				m.EventViewMenu = false
 				m.EventAddFocusField = 0
@@ -351,16 +529,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case TickMsg:
 		m.LastUpdate = time.Time(msg)
-		m.pollNodes()
+		m.eachHub(func() {
+			m.checkHubConnection()
+			m.pollNodes()
+		})
 		m.updateAchtungRemaining()
-		if m.Hub != nil && m.Hub.Connected() && time.Since(m.LastAchtungSync) >= achtungSyncEvery {
-			m.requestAchtungList()
-			m.LastAchtungSync = time.Now()
-		}
+		m.notifyDeadlinesToday()
+		m.rotateKiosk()
+		m.onHub(0, func() {
+			if m.hubConnected() && time.Since(m.LastAchtungSync) >= achtungSyncEvery {
+				m.requestAchtungList()
+				m.LastAchtungSync = time.Now()
+			}
+		})
+		m.saveStateCache()
+		m.eachHub(m.saveNodeHistory)
 		return m, m.scheduleNextCmds()
 
+	case diagMsg:
+		m.handleDiagMsg(msg)
+		return m, nil
+	case hubDialedMsg:
+		m.handleHubDialed(msg)
+		return m, nil
 	case HubMsg:
-		m.handleHub(monolink.Message(msg))
+		m.onHub(m.hubIndex(msg.Hub), func() {
+			m.checkHubConnection()
+			m.handleHub(msg.Msg)
+		})
 		m.updateAchtungRemaining()
 		return m, m.scheduleNextCmds()
 	}
@@ -368,16 +564,49 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// showSheet switches to sheet s, leaving the previous sheet's modes.
+func (m *Model) showSheet(s types.Sheet) {
+	m.ActiveSheet = s
+	m.SystemCommandInput = false
+	switch s {
+	case types.SheetCalendar:
+		m.CalendarFocusEvents = false
+		m.EventViewMenu = false
+		if m.EventAddMenu {
+			m.eventAddReset()
+		}
+	case types.SheetHome:
+		m.requestAchtungList()
+	case types.SheetSystem:
+		m.SystemFocusLogs = false
+	case types.SheetDeadlines:
+		m.clampSelectedDeadline()
+	}
+}
+
 // handleHub processes an incoming concentrator message and updates model state.
 func (m *Model) handleHub(msg monolink.Message) {
-	now := time.Now()
-	m.LastRx = now
+	m.LastRx = time.Now()
+	m.addLog("MSG", msg.From, msg.Raw)
+
+	m.handleNodeDiscovery(msg)
+	m.recordNodeMessage(msg.From, false, msg.Raw)
+	m.recordNodeValue(msg)
+	m.handleNodeResponse(msg)
+	m.handleGovernorResponse(msg)
+	m.handleDeviceResponse(msg)
+	m.handleAchtungResponse(msg)
+	m.handleFireAlert(msg)
+}
 
+// addLog prepends a line to the System sheet log pane and writes it to the log file.
+func (m *Model) addLog(level, source, message string) {
+	m.log(paneLevel(level), message, "source", source)
 	m.Logs = append([]types.LogEntry{{
-		Time:    now,
-		Level:   "MSG",
-		Source:  msg.From,
-		Message: msg.Raw,
+		Time:    time.Now(),
+		Level:   level,
+		Source:  source,
+		Message: message,
 	}}, m.Logs...)
 
 	const maxLogs = 50
@@ -391,20 +620,24 @@ func (m *Model) handleHub(msg monolink.Message) {
 			m.LogScrollOffset = len(m.Logs) - 1
 		}
 	}
-
-	m.handleNodeResponse(msg)
-	m.handleGovernorResponse(msg)
-	m.handleDeviceResponse(msg)
-	m.handleAchtungResponse(msg)
-	m.handleFireAlert(msg)
 }
 
 // HubSend is a convenience for sending a command through the concentrator
 // from any place that has access to the Model (key handlers, etc.).
+// The command goes out on the hub that to is on.
 func (m *Model) HubSend(to, verb, noun string, args ...string) {
+	if i := m.nodeHub(to); i != m.index {
+		m.onHub(i, func() { m.HubSend(to, verb, noun, args...) })
+		return
+	}
 	if m.Hub != nil {
-		m.Hub.Send(to, verb, noun, args...)
+		if err := m.Hub.Send(to, verb, noun, args...); err != nil {
+			m.log(slog.LevelWarn, "send failed", "to", to, "verb", verb, "noun", noun, "err", err)
+		} else {
+			m.log(slog.LevelDebug, "send", "to", to, "verb", verb, "noun", noun, "args", args)
+		}
 		m.LastTx = time.Now()
+		m.recordNodeMessage(to, true, strings.Join(append([]string{to, verb, noun}, args...), ":"))
 	}
 }
 
@@ -547,7 +780,7 @@ func (m *Model) deleteSelectedEvent() {
 	if id == "" {
 		return
 	}
-	m.HubSend("GOVERNOR", "STOP", "EVENT", id)
+	m.sendOrQueue("GOVERNOR", "STOP", "EVENT", id)
 	m.requestGovernorEvents()
 	m.requestGovernorDeadlines()
 	if m.SelectedEvent >= len(dayEvents)-1 {
@@ -0,0 +1,260 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"monoview/internal/types"
+)
+
+// ACHTUNG job actions built from NEW/STOP: pause/resume and extend for
+// timers and alarms, and snooze from the fire alert. ACHTUNG has no pause of
+// its own, so a paused timer is stopped on the node and kept here with its
+// remaining time until resumed.
+
+const (
+	achtungExtendBy = 5 * time.Minute
+	defaultSnooze   = 5 * time.Minute
+)
+
+// achtungDurationArg formats a duration for NEW:TIMER (e.g. "4m3s").
+func achtungDurationArg(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d < time.Second {
+		d = time.Second
+	}
+	return d.String()
+}
+
+func (m *Model) selectedAchtungJob() *types.AchtungJob {
+	if m.SelectedAchtungJob < 0 || m.SelectedAchtungJob >= len(m.AchtungJobs) {
+		return nil
+	}
+	return &m.AchtungJobs[m.SelectedAchtungJob]
+}
+
+// achtungTogglePause pauses a running timer (STOP, keep remaining) or resumes
+// a paused one (NEW:TIMER with the remaining time).
+func (m *Model) achtungTogglePause() {
+	job := m.selectedAchtungJob()
+	if job == nil || job.Kind != "TIMER" {
+		return
+	}
+	now := time.Now()
+	if job.Paused {
+		end := now.Add(job.PausedLeft)
+		m.recreateJob("TIMER", job.Name, achtungDurationArg(job.PausedLeft))
+		job.Paused = false
+		job.PausedLeft = 0
+		job.EndTime = &end
+		m.updateJobRemaining(job)
+		return
+	}
+	if job.EndTime == nil {
+		return
+	}
+	left := job.EndTime.Sub(now)
+	if left <= 0 {
+		return
+	}
+	m.sendOrQueue("ACHTUNG", "STOP", "TIMER", job.Name)
+	job.Paused = true
+	job.PausedLeft = left
+	job.EndTime = nil
+	job.Remaining = "paused " + formatDuration(left)
+}
+
+// achtungExtendSelected pushes the selected job back by achtungExtendBy:
+// timers are re-created with remaining+5m, alarms re-armed 5m later.
+func (m *Model) achtungExtendSelected() {
+	job := m.selectedAchtungJob()
+	if job == nil {
+		return
+	}
+	if job.Paused {
+		job.PausedLeft += achtungExtendBy
+		job.Remaining = "paused " + formatDuration(job.PausedLeft)
+		return
+	}
+	if job.EndTime == nil {
+		return
+	}
+	end := job.EndTime.Add(achtungExtendBy)
+	if !m.achtungRearm(job.Kind, job.Name, end, true) {
+		return
+	}
+	if job.Total > 0 {
+		job.Total += achtungExtendBy
+	}
+	job.EndTime = &end
+	if job.Kind == "ALARM" {
+		job.Due = end.Format("2006.01.02:15.04")
+	}
+	m.updateJobRemaining(job)
+}
+
+// achtungRearm (re)creates a job on ACHTUNG ending at end, stopping the
+// existing one first when replace is set. Returns false for unknown kinds.
+func (m *Model) achtungRearm(kind, name string, end time.Time, replace bool) bool {
+	kind = strings.ToUpper(kind)
+	if kind != "TIMER" && kind != "ALARM" {
+		return false
+	}
+	if replace {
+		m.sendOrQueue("ACHTUNG", "STOP", kind, name)
+	}
+	if kind == "TIMER" {
+		m.recreateJob("TIMER", name, achtungDurationArg(time.Until(end)))
+		if !replace {
+			m.noteTimerTotal(name, time.Until(end))
+		}
+	} else {
+		m.recreateJob("ALARM", name, formatAchtungAlarmDateTime(end.Format("2006-01-02"), end.Format("15:04")))
+	}
+	return true
+}
+
+// snoozeFireAlert acknowledges the selected alert and re-arms its job SnoozeFor from now.
+func (m *Model) snoozeFireAlert() {
+	a := m.selectedFireAlert()
+	if a == nil {
+		return
+	}
+	alert := *a
+	m.ackFireAlert("snoozed")
+	end := time.Now().Add(m.snoozeFor())
+	if alert.JobKind == "ALARM" {
+		// Alarm minutes are whole; round up so the snooze is never shorter.
+		end = end.Truncate(time.Minute).Add(time.Minute)
+	}
+	name := alert.JobName
+	if m.recurringAlarm(name) != nil {
+		// The next occurrence already holds the name.
+		name += "_snooze"
+	}
+	if m.achtungRearm(alert.JobKind, name, end, false) {
+		m.AchtungJobs = append(m.AchtungJobs, types.AchtungJob{
+			Kind:      alert.JobKind,
+			Name:      name,
+			Remaining: formatDuration(time.Until(end)),
+			Due:       "—",
+			EndTime:   &end,
+			Total:     time.Until(end),
+		})
+	}
+	m.requestAchtungList()
+}
+
+func (m *Model) snoozeFor() time.Duration {
+	if m.SnoozeFor > 0 {
+		return m.SnoozeFor
+	}
+	return defaultSnooze
+}
+
+// keepPausedJobs carries locally paused timers over a fresh LIST (ACHTUNG no
+// longer knows them since they were stopped).
+func keepPausedJobs(prev, fresh []types.AchtungJob) []types.AchtungJob {
+	for _, p := range prev {
+		if !p.Paused {
+			continue
+		}
+		found := false
+		for _, f := range fresh {
+			if f.Name == p.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fresh = append(fresh, p)
+		}
+	}
+	return fresh
+}
+
+// noteTimerTotal remembers the length of a timer we just created so the next
+// LIST can show its progress from the start.
+func (m *Model) noteTimerTotal(name string, d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	if m.achtungTotals == nil {
+		m.achtungTotals = map[string]time.Duration{}
+	}
+	m.achtungTotals[name] = d
+}
+
+// uniqueTimerName returns name, or name_2, name_3, ... when a timer of that
+// name is already listed or was just created here, so picking the same preset
+// twice starts a second timer instead of a second NEW for the first.
+func (m *Model) uniqueTimerName(name string) string {
+	taken := func(n string) bool {
+		if _, ok := m.achtungTotals[n]; ok {
+			return true
+		}
+		for _, j := range m.AchtungJobs {
+			if j.Kind == "TIMER" && j.Name == n {
+				return true
+			}
+		}
+		return false
+	}
+	unique := name
+	for i := 2; taken(unique); i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	return unique
+}
+
+// carryTimerTotals fills Total for freshly listed jobs from the previous list
+// or from timers created here.
+func (m *Model) carryTimerTotals(fresh []types.AchtungJob) {
+	for i := range fresh {
+		for _, p := range m.AchtungJobs {
+			if p.Name == fresh[i].Name && p.Total > 0 {
+				fresh[i].Total = p.Total
+				break
+			}
+		}
+		if d, ok := m.achtungTotals[fresh[i].Name]; ok {
+			if fresh[i].Total == 0 {
+				fresh[i].Total = d
+			}
+			delete(m.achtungTotals, fresh[i].Name)
+		}
+	}
+}
+
+// handleAchtungBigViewKeys handles keys while the full-screen countdown is open.
+func (m *Model) handleAchtungBigViewKeys(msg tea.KeyMsg) bool {
+	if !m.AchtungBigView {
+		return false
+	}
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return false
+	case "f", "esc", "enter":
+		m.AchtungBigView = false
+	case "j", "down":
+		if m.SelectedAchtungJob < len(m.AchtungJobs)-1 {
+			m.SelectedAchtungJob++
+		}
+	case "k", "up":
+		if m.SelectedAchtungJob > 0 {
+			m.SelectedAchtungJob--
+		}
+	case "p":
+		m.achtungTogglePause()
+	case "e":
+		m.achtungExtendSelected()
+	}
+	return true
+}
+
+func formatSnooze(d time.Duration) string {
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
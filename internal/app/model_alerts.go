@@ -0,0 +1,214 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"monoview/internal/config"
+	"monoview/internal/notify"
+	"monoview/internal/props"
+	"monoview/internal/types"
+)
+
+// Node alerts: the config's alert rules are checked whenever a node's state
+// changes (status, RTT, uptime) or it reports a watched property. A raised
+// alert is shown in the header on every sheet until acknowledged ([!] lists
+// them), goes to the "alert" notifiers, and optionally to the hub (ALL:ALERT)
+// and a shell hook. Conditions that stop holding resolve their alert;
+// acknowledged and resolved alerts are dropped, and so are resolved ones no
+// one acknowledged after resolvedAlertTTL (a kiosk cannot acknowledge).
+
+const (
+	maxAlerts        = 100 // alerts kept per hub, oldest dropped first
+	resolvedAlertTTL = 24 * time.Hour
+)
+
+// activeAlert returns the unresolved alert of rule on node, if any.
+func (m *Model) activeAlert(rule int, node string) *types.NodeAlert {
+	for i := range m.Alerts {
+		a := &m.Alerts[i]
+		if a.Rule == rule && a.Hub == m.hubLabel() && a.Node == node && a.Resolved.IsZero() {
+			return a
+		}
+	}
+	return nil
+}
+
+// checkNodeAlerts raises or resolves the offline and rtt alerts of node.
+// Called after every probe result and on each tick.
+func (m *Model) checkNodeAlerts(node *types.SystemNode) {
+	now := m.LastUpdate
+	if now.IsZero() {
+		now = time.Now()
+	}
+	for i, r := range m.Config.Alerts {
+		if !r.AppliesTo(node.Name) {
+			continue
+		}
+		forDur, above := r.Durations()
+		var holds bool
+		var detail string
+		switch r.When {
+		case config.AlertOffline:
+			holds = node.Status == "offline" && !node.Since.IsZero() && now.Sub(node.Since) >= forDur
+			detail = "offline since " + node.Since.Format("15:04")
+		case config.AlertRTT:
+			holds = (node.Status == "online" || node.Status == "degraded") && node.PingMs > above.Milliseconds()
+			detail = fmt.Sprintf("RTT %dms", node.PingMs)
+		default:
+			continue
+		}
+		a := m.activeAlert(i, node.Name)
+		switch {
+		case holds && a == nil:
+			m.raiseAlert(i, r, node.Name, detail)
+		case !holds && a != nil:
+			a.Resolved = now
+			m.addLog("INFO", "ALERT", fmt.Sprintf("%s %s resolved", node.Name, a.Label))
+		}
+	}
+	m.pruneAlerts()
+}
+
+// checkPropertyAlerts raises or resolves the value alerts watching p.
+func (m *Model) checkPropertyAlerts(p props.Path) {
+	v, ok := m.Props.Get(p)
+	if !ok {
+		return
+	}
+	for i, r := range m.Config.Alerts {
+		if r.When != config.AlertValue {
+			continue
+		}
+		// validate has checked the path.
+		if watch, _ := props.ParsePath(r.Property); watch != p {
+			continue
+		}
+		holds := r.Holds(v.Value)
+		a := m.activeAlert(i, p.Node)
+		switch {
+		case holds && a == nil:
+			m.raiseAlert(i, r, p.Node, p.String()+" is "+v.Value)
+		case !holds && a != nil:
+			a.Resolved = v.At
+			m.addLog("INFO", "ALERT", fmt.Sprintf("%s %s resolved", p.Node, a.Label))
+		}
+	}
+	m.pruneAlerts()
+}
+
+// alertReboot raises the reboot alerts of node; up is its new uptime.
+func (m *Model) alertReboot(node string, up time.Duration) {
+	for i, r := range m.Config.Alerts {
+		if r.When != config.AlertReboot || !r.AppliesTo(node) {
+			continue
+		}
+		m.raiseAlert(i, r, node, "rebooted "+formatDuration(up)+" ago")
+		// A reboot is over as soon as it is seen; the alert stays until acknowledged.
+		m.Alerts[len(m.Alerts)-1].Resolved = m.Alerts[len(m.Alerts)-1].Raised
+	}
+}
+
+// raiseAlert records a new alert and runs the rule's actions.
+func (m *Model) raiseAlert(rule int, r config.AlertRule, node, detail string) {
+	a := types.NodeAlert{
+		Rule:   rule,
+		Label:  r.Label(),
+		Hub:    m.hubLabel(),
+		Node:   node,
+		Detail: detail,
+		Raised: time.Now(),
+	}
+	m.Alerts = append(m.Alerts, a)
+	m.addLog("WARN", "ALERT", fmt.Sprintf("%s %s: %s", node, a.Label, detail))
+	title := "Alert: " + alertNode(a) + " " + a.Label
+	m.notify(notify.KindAlert, title, detail)
+	if r.Broadcast {
+		// ':' separates frame fields, so the detail (often a time) stays
+		// local and a label that has one is flattened.
+		m.HubSend("ALL", "ALERT", node, strings.ReplaceAll(a.Label, ":", " "))
+	}
+	if r.Hook != "" {
+		hook := notify.Hook{Command: r.Hook}
+		if err := hook.Notify(notify.Event{Kind: notify.KindAlert, Title: title, Body: detail}); err != nil {
+			m.addLog("WARN", "ALERT", "hook: "+err.Error())
+		}
+	}
+}
+
+// pruneAlerts drops alerts that are acknowledged and resolved or resolved
+// long ago, and the oldest beyond maxAlerts.
+func (m *Model) pruneAlerts() {
+	now := time.Now()
+	kept := m.Alerts[:0]
+	for _, a := range m.Alerts {
+		if a.Resolved.IsZero() || (!a.Acked && now.Sub(a.Resolved) < resolvedAlertTTL) {
+			kept = append(kept, a)
+		}
+	}
+	if len(kept) > maxAlerts {
+		kept = kept[len(kept)-maxAlerts:]
+	}
+	m.Alerts = kept
+	if m.SelectedAlert >= len(m.Alerts) {
+		m.SelectedAlert = len(m.Alerts) - 1
+	}
+	if m.SelectedAlert < 0 {
+		m.SelectedAlert = 0
+	}
+}
+
+// unackedAlerts counts the alerts shown in the header banner.
+func (m Model) unackedAlerts() int {
+	n := 0
+	for _, a := range m.Alerts {
+		if !a.Acked {
+			n++
+		}
+	}
+	return n
+}
+
+func (m *Model) ackAlert(i int) {
+	if i < 0 || i >= len(m.Alerts) {
+		return
+	}
+	m.Alerts[i].Acked = true
+	m.pruneAlerts()
+}
+
+func (m *Model) ackAllAlerts() {
+	for i := range m.Alerts {
+		m.Alerts[i].Acked = true
+	}
+	m.pruneAlerts()
+}
+
+// handleAlertKeys handles the alert list: [j/k] select, [Enter]/[a] acknowledge, [A] all, [Esc]/[!] close.
+func (m *Model) handleAlertKeys(msg tea.KeyMsg) bool {
+	if !m.AlertsView {
+		return false
+	}
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return false
+	case "esc", "!":
+		m.AlertsView = false
+	case "j", "down":
+		if m.SelectedAlert < len(m.Alerts)-1 {
+			m.SelectedAlert++
+		}
+	case "k", "up":
+		if m.SelectedAlert > 0 {
+			m.SelectedAlert--
+		}
+	case "enter", " ", "a":
+		m.ackAlert(m.SelectedAlert)
+	case "A":
+		m.ackAllAlerts()
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"time"
+
+	"monoview/internal/store"
+	"monoview/internal/types"
+	"monoview/internal/ui"
+)
+
+// Offline cache: last known GOVERNOR and ACHTUNG state, written to the XDG
+// cache dir and shown at startup (marked stale) until fresh replies arrive.
+
+const stateCacheFile = "state.json"
+
+type stateCache struct {
+	SavedAt     time.Time
+	Events      []types.Event
+	Deadlines   []types.Event
+	Schedule    []types.ScheduleEntry
+	AchtungJobs []types.AchtungJob
+}
+
+// loadStateCache fills empty GOVERNOR/ACHTUNG state from the cache file and
+// marks it stale. Timers whose end time has passed are dropped.
+func (m *Model) loadStateCache() {
+	path, err := store.CachePath(stateCacheFile)
+	if err != nil {
+		return
+	}
+	var c stateCache
+	if err := store.Load(path, &c); err != nil || c.SavedAt.IsZero() {
+		return
+	}
+	if len(c.Events) > 0 || len(c.Deadlines) > 0 || len(c.Schedule) > 0 {
+		m.Events = c.Events
+		m.Deadlines = c.Deadlines
+		m.Schedule = c.Schedule
+		m.CalendarStaleSince = c.SavedAt
+	}
+	now := time.Now()
+	var jobs []types.AchtungJob
+	for _, j := range c.AchtungJobs {
+		if j.EndTime != nil && !j.EndTime.After(now) {
+			continue
+		}
+		jobs = append(jobs, j)
+	}
+	if len(jobs) > 0 {
+		m.AchtungJobs = jobs
+		m.AchtungStaleSince = c.SavedAt
+	}
+}
+
+// saveStateCache writes the current state when something changed since the last save.
+func (m *Model) saveStateCache() {
+	if !m.stateCacheDirty {
+		return
+	}
+	path, err := store.CachePath(stateCacheFile)
+	if err != nil {
+		return
+	}
+	c := stateCache{
+		SavedAt:     time.Now(),
+		Events:      m.Events,
+		Deadlines:   m.Deadlines,
+		Schedule:    m.Schedule,
+		AchtungJobs: m.AchtungJobs,
+	}
+	if err := store.Save(path, c); err == nil {
+		m.stateCacheDirty = false
+	}
+}
+
+// markCalendarFresh is called when GOVERNOR answers: cached calendar data is replaced.
+func (m *Model) markCalendarFresh() {
+	m.CalendarStaleSince = time.Time{}
+	m.stateCacheDirty = true
+}
+
+// markAchtungFresh is called when ACHTUNG answers: cached jobs are replaced.
+func (m *Model) markAchtungFresh() {
+	m.AchtungStaleSince = time.Time{}
+	m.stateCacheDirty = true
+}
+
+// staleMarker renders "stale since HH:MM" for cached data, or "" when fresh.
+func staleMarker(since time.Time) string {
+	if since.IsZero() {
+		return ""
+	}
+	layout := "15:04"
+	now := time.Now()
+	if now.YearDay() != since.YearDay() || now.Year() != since.Year() {
+		layout = "02 Jan 15:04"
+	}
+	return ui.Warning.Render("stale since " + since.Format(layout))
+}
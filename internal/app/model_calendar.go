@@ -2,6 +2,7 @@ package app
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -46,7 +47,8 @@ func (m *Model) handleGovernorResponse(msg monolink.Message) {
 }
 
 func (m *Model) handleGovernorSchedule(msg monolink.Message) {
-	entries := parseGovernorScheduleSlots(msg.Args)
+	entries, errs := parseGovernorScheduleSlots(msg.Args)
+	m.rejectItems(msg, errs)
 	if len(entries) == 0 {
 		return
 	}
@@ -59,11 +61,16 @@ func (m *Model) handleGovernorSchedule(msg monolink.Message) {
 	}
 	m.Schedule = append(rest, entries...)
 	sortSchedule(m.Schedule)
+	m.stateCacheDirty = true
 }
 
 func (m *Model) handleGovernorEvents(msg monolink.Message) {
-	events := parseGovernorEvents(msg.Args)
+	events, errs := parseGovernorEvents(msg.Args)
+	m.rejectItems(msg, errs)
 	m.Events = events
+	m.markCalendarFresh()
+	m.replayWaitEvents = false
+	m.checkReplay()
 	dayEvents := m.eventsForSelectedDate()
 	if m.SelectedEvent >= len(dayEvents) {
 		m.SelectedEvent = len(dayEvents) - 1
@@ -95,6 +102,7 @@ func (m *Model) handleGovernorEventCreated(msg monolink.Message) {
 		Notes:    m.EventAddNotes,
 	})
 	sortEvents(m.Events)
+	m.stateCacheDirty = true
 	m.eventAddReset()
 	m.requestGovernorDeadlines()
 }
@@ -110,80 +118,47 @@ func (m *Model) eventAddReset() {
 	m.EventAddVisibleFrom = ""
 }
 
-func (m *Model) eventAddFocusedValue() *string {
-	switch m.EventAddFocusField {
-	case 0:
-		return &m.EventAddTitle
-	case 1:
-		return &m.EventAddDate
-	case 2:
-		return &m.EventAddTime
-	case 3:
-		return &m.EventAddLocation
-	case 4:
-		return &m.EventAddNotes
-	case 5:
-		return &m.EventAddVisibleFrom
-	default:
-		return &m.EventAddTitle
+// eventAddFields describes the add-event form: 0=title, 1=date, 2=time, 3=location, 4=notes, 5=visible_from.
+func (m *Model) eventAddFields() []formField {
+	return []formField{
+		{Label: "Title", Value: &m.EventAddTitle, Kind: fieldText},
+		{Label: "Date (YYYY-MM-DD)", Value: &m.EventAddDate, Kind: fieldDate},
+		{Label: "Time (HH:MM)", Value: &m.EventAddTime, Kind: fieldTime},
+		{Label: "Location", Value: &m.EventAddLocation, Kind: fieldText, Optional: true},
+		{Label: "Notes", Value: &m.EventAddNotes, Kind: fieldText, Optional: true},
+		{Label: "Visible from (opt)", Value: &m.EventAddVisibleFrom, Kind: fieldDate, Optional: true, Check: m.checkEventVisibleFrom},
 	}
 }
 
+// checkEventVisibleFrom rejects a visible-from date after the event date.
+func (m *Model) checkEventVisibleFrom(v string) string {
+	from, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		return ""
+	}
+	if date, err := time.Parse("2006-01-02", m.EventAddDate); err == nil && from.After(date) {
+		return "must not be after the event date"
+	}
+	return ""
+}
+
 func (m *Model) handleEventAddKeys(msg tea.KeyMsg) bool {
 	if !m.EventAddMenu || m.ActiveSheet != types.SheetCalendar {
 		return false
 	}
-	key := msg.String()
-	switch key {
-	case "esc":
+	if msg.String() == "esc" {
 		m.eventAddReset()
 		return true
-	case "tab":
-		m.EventAddFocusField = (m.EventAddFocusField + 1) % 6
-		return true
-	case "shift+tab":
-		m.EventAddFocusField = (m.EventAddFocusField + 5) % 6
-		return true
-	case "enter":
-		if m.EventAddFocusField == 5 {
-			if m.eventAddValidateAndSubmit() {
-				return true
-			}
-		}
-		m.EventAddFocusField = (m.EventAddFocusField + 1) % 6
-		return true
-	case "backspace":
-		s := m.eventAddFocusedValue()
-		runes := []rune(*s)
-		if len(runes) > 0 {
-			*s = string(runes[:len(runes)-1])
-		}
-		return true
-	case " ":
-		*m.eventAddFocusedValue() += " "
-		return true
-	}
-	if msg.Type == tea.KeyRunes && len(msg.Runes) > 0 {
-		*m.eventAddFocusedValue() += string(msg.Runes)
-		return true
 	}
+	handleFormKeys(msg, m.eventAddFields(), &m.EventAddFocusField, func() { m.eventAddValidateAndSubmit() })
 	return true
 }
 
+// eventAddValidateAndSubmit sends the event when every field is valid;
+// otherwise it focuses the first invalid field (its error is shown inline).
 func (m *Model) eventAddValidateAndSubmit() bool {
-	if strings.TrimSpace(m.EventAddTitle) == "" {
-		return true
-	}
-	if _, err := time.Parse("2006-01-02", m.EventAddDate); err != nil {
-		return true
-	}
-	if m.EventAddTime != "" {
-		if _, err := time.Parse("15:04", m.EventAddTime); err != nil {
-			if _, err2 := time.Parse("15:04:05", m.EventAddTime); err2 != nil {
-				return true
-			}
-		}
-	} else {
+	if bad := rejectedField(m.eventAddFields()); bad >= 0 {
+		m.EventAddFocusField = bad
 		return true
 	}
 	m.eventAddSubmit()
@@ -203,19 +178,30 @@ func (m *Model) eventAddSubmit() {
 	if m.EventAddVisibleFrom != "" {
 		args = append(args, strings.ReplaceAll(m.EventAddVisibleFrom, "-", "."))
 	}
-	m.HubSend("GOVERNOR", "NEW", "EVENT", args...)
+	if m.sendOrQueue("GOVERNOR", "NEW", "EVENT", args...) {
+		// No OK:EVENT will arrive while offline; close the form now.
+		m.eventAddReset()
+	}
 }
 
 func (m *Model) handleGovernorDeadlines(msg monolink.Message) {
-	m.Deadlines = parseGovernorEvents(msg.Args)
+	deadlines, errs := parseGovernorEvents(msg.Args)
+	m.rejectItems(msg, errs)
+	m.Deadlines = deadlines
 	sortEvents(m.Deadlines)
+	m.stateCacheDirty = true
+	m.clampSelectedDeadline()
 }
 
-func parseGovernorEvents(args []string) []types.Event {
+// parseGovernorEvents parses ID|TITLE|TIME[|LOCATION[|NOTES]] items. Items
+// that do not parse are left out and returned as errors.
+func parseGovernorEvents(args []string) ([]types.Event, []error) {
 	var out []types.Event
+	var errs []error
 	for _, arg := range args {
 		parts := strings.Split(arg, "|")
 		if len(parts) < 3 {
+			errs = append(errs, fmt.Errorf("event %q: want ID|TITLE|TIME", arg))
 			continue
 		}
 		id := parts[0]
@@ -231,11 +217,14 @@ func parseGovernorEvents(args []string) []types.Event {
 		}
 		t, err := parseGovernorEventTime(atStr)
 		if err != nil {
+			errs = append(errs, fmt.Errorf("event %q: %w", arg, err))
 			continue
 		}
 		category := "personal"
 		if strings.Contains(strings.ToLower(notes), "deadline") {
 			category = "deadline"
+		} else if strings.Contains(strings.ToLower(notes), "holiday") {
+			category = "holiday"
 		} else if strings.Contains(strings.ToLower(notes), "work") {
 			category = "work"
 		} else if strings.Contains(strings.ToLower(notes), "system") {
@@ -251,28 +240,44 @@ func parseGovernorEvents(args []string) []types.Event {
 		})
 	}
 	sortEvents(out)
-	return out
+	return out, errs
 }
 
+// parseGovernorEventTime parses YYYY:MM:DD:HH:MM[:SS] (dots or colons). Each
+// part must be a number in range: month 13 or 30 February is an error, not
+// normalized into the next month.
 func parseGovernorEventTime(s string) (time.Time, error) {
 	s = strings.TrimSpace(s)
 	s = strings.ReplaceAll(s, ".", ":")
 	parts := strings.Split(s, ":")
-	if len(parts) < 5 {
-		return time.Time{}, fmt.Errorf("need at least YYYY:MM:DD:HH:MM")
-	}
-	var y, mo, d, h, min, sec int
-	fmt.Sscanf(parts[0], "%d", &y)
-	fmt.Sscanf(parts[1], "%d", &mo)
-	fmt.Sscanf(parts[2], "%d", &d)
-	fmt.Sscanf(parts[3], "%d", &h)
-	fmt.Sscanf(parts[4], "%d", &min)
-	if len(parts) >= 6 {
-		fmt.Sscanf(parts[5], "%d", &sec)
+	if len(parts) < 5 || len(parts) > 6 {
+		return time.Time{}, fmt.Errorf("time %q: want YYYY:MM:DD:HH:MM[:SS]", s)
+	}
+	var n [6]int
+	for i, p := range parts {
+		v, err := strconv.Atoi(p)
+		if err != nil || v < 0 {
+			return time.Time{}, fmt.Errorf("time %q: %q is not a number", s, p)
+		}
+		n[i] = v
+	}
+	y, mo, d, h, min, sec := n[0], n[1], n[2], n[3], n[4], n[5]
+	switch {
+	case mo < 1 || mo > 12:
+		return time.Time{}, fmt.Errorf("time %q: month %d out of range", s, mo)
+	case d < 1 || d > daysIn(time.Month(mo), y):
+		return time.Time{}, fmt.Errorf("time %q: day %d out of range", s, d)
+	case h > 23 || min > 59 || sec > 59:
+		return time.Time{}, fmt.Errorf("time %q: %02d:%02d:%02d is not a time of day", s, h, min, sec)
 	}
 	return time.Date(y, time.Month(mo), d, h, min, sec, 0, time.Local), nil
 }
 
+// daysIn returns the number of days of month in year.
+func daysIn(month time.Month, year int) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
 func sortEvents(events []types.Event) {
 	for i := 0; i < len(events); i++ {
 		for j := i + 1; j < len(events); j++ {
@@ -283,16 +288,28 @@ func sortEvents(events []types.Event) {
 	}
 }
 
-func parseGovernorScheduleSlots(args []string) []types.ScheduleEntry {
+// parseGovernorScheduleSlots parses DAY|START|END|TITLE|LOCATION|TAGS items.
+// Items that do not parse are left out and returned as errors.
+func parseGovernorScheduleSlots(args []string) ([]types.ScheduleEntry, []error) {
 	var entries []types.ScheduleEntry
+	var errs []error
 	for _, arg := range args {
 		parts := strings.Split(arg, "|")
 		if len(parts) < 6 {
+			errs = append(errs, fmt.Errorf("slot %q: want DAY|START|END|TITLE|LOCATION|TAGS", arg))
+			continue
+		}
+		wd, ok := parseWeekday(parts[0])
+		if !ok {
+			errs = append(errs, fmt.Errorf("slot %q: unknown weekday %q", arg, parts[0]))
 			continue
 		}
-		wd := parseWeekday(parts[0])
 		start := strings.ReplaceAll(parts[1], ".", ":")
 		end := strings.ReplaceAll(parts[2], ".", ":")
+		if err := checkClock(start, end); err != nil {
+			errs = append(errs, fmt.Errorf("slot %q: %w", arg, err))
+			continue
+		}
 		title := parts[3]
 		location := parts[4]
 		tags := strings.Split(parts[5], ";")
@@ -308,28 +325,44 @@ func parseGovernorScheduleSlots(args []string) []types.ScheduleEntry {
 			Tags:     tags,
 		})
 	}
-	return entries
+	return entries, errs
+}
+
+// checkClock checks that a slot's start and end are HH:MM. An end at or
+// before the start runs past midnight (22:00–00:00, 23:00–01:00), and 24:00
+// is accepted as an end.
+func checkClock(start, end string) error {
+	if _, err := time.Parse("15:04", start); err != nil {
+		return fmt.Errorf("start %q is not HH:MM", start)
+	}
+	if end == "24:00" {
+		return nil
+	}
+	if _, err := time.Parse("15:04", end); err != nil {
+		return fmt.Errorf("end %q is not HH:MM", end)
+	}
+	return nil
 }
 
-func parseWeekday(s string) time.Weekday {
+// parseWeekday parses a three-letter weekday (mon … sun).
+func parseWeekday(s string) (time.Weekday, bool) {
 	switch strings.ToLower(strings.TrimSpace(s)) {
 	case "mon":
-		return time.Monday
+		return time.Monday, true
 	case "tue":
-		return time.Tuesday
+		return time.Tuesday, true
 	case "wed":
-		return time.Wednesday
+		return time.Wednesday, true
 	case "thu":
-		return time.Thursday
+		return time.Thursday, true
 	case "fri":
-		return time.Friday
+		return time.Friday, true
 	case "sat":
-		return time.Saturday
+		return time.Saturday, true
 	case "sun":
-		return time.Sunday
-	default:
-		return time.Sunday
+		return time.Sunday, true
 	}
+	return time.Sunday, false
 }
 
 func sortSchedule(s []types.ScheduleEntry) {
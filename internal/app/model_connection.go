@@ -0,0 +1,169 @@
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/MrZloHex/monolink"
+	"monoview/internal/diag"
+	"monoview/internal/types"
+)
+
+// Connection diagnostics: [c] on the System sheet shows how the active hub
+// is dialed, its last error, and a probe of each step of reaching it (DNS,
+// TCP, TLS with the server chain and client certificate, WebSocket upgrade).
+// The probe runs when the view opens and on [r], which also dials the hub
+// again while it is offline, whether it was never reached or dropped.
+
+// diagTimeout bounds one probe or redial.
+const diagTimeout = 10 * time.Second
+
+// HubDial is how a hub is reached.
+type HubDial struct {
+	URL        string
+	ServerName string      // --tls-server-name / tls_server_name, "" for the URL host
+	TLS        *tls.Config // client TLS config; nil without mTLS
+	// Connect dials a new client, used by [r] for a hub that was unreachable at startup.
+	Connect func(ctx context.Context) (*monolink.Client, error)
+	// Adopt is told about a client from Connect once the model takes it over
+	// (one it turns down is closed instead); nil when nobody needs to know.
+	Adopt func(*monolink.Client)
+}
+
+// diagMsg carries a finished probe.
+type diagMsg struct {
+	Hub    string
+	Report diag.Report
+}
+
+// hubDialedMsg carries the result of dialing a hub again.
+type hubDialedMsg struct {
+	Hub    string
+	Client *monolink.Client
+	Err    error
+}
+
+// SetHubDial records how the named hub is dialed and the error of the first
+// attempt (nil if it connected).
+func (m *Model) SetHubDial(name string, d HubDial, err error) {
+	m.onHub(m.hubIndex(name), func() {
+		m.Dial = d
+		if err != nil {
+			m.noteHubError(err.Error())
+		}
+	})
+}
+
+// noteHubError remembers the latest connection error of the current hub.
+func (m *Model) noteHubError(msg string) {
+	m.LastErr = msg
+	m.LastErrAt = time.Now()
+}
+
+// probeHub starts a probe of the current hub unless one is running.
+func (m *Model) probeHub() tea.Cmd {
+	if m.diagRunning || m.Dial.URL == "" {
+		return nil
+	}
+	m.diagRunning = true
+	name, d := m.Name, m.Dial
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), diagTimeout)
+		defer cancel()
+		return diagMsg{Hub: name, Report: diag.Probe(ctx, d.URL, d.TLS, d.ServerName)}
+	}
+}
+
+// retryHub probes the current hub and, while it is offline, dials it again.
+func (m *Model) retryHub() tea.Cmd {
+	cmds := []tea.Cmd{m.probeHub()}
+	if !m.hubConnected() && m.Dial.Connect != nil && !m.dialing {
+		m.dialing = true
+		name, connect := m.Name, m.Dial.Connect
+		cmds = append(cmds, func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), diagTimeout)
+			defer cancel()
+			client, err := connect(ctx)
+			return hubDialedMsg{Hub: name, Client: client, Err: err}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleDiagMsg stores a probe; a failed step becomes the hub's last error.
+func (m *Model) handleDiagMsg(msg diagMsg) {
+	m.onHub(m.hubIndex(msg.Hub), func() {
+		m.diagRunning = false
+		m.Diag = &msg.Report
+		for _, s := range msg.Report.Steps {
+			if !s.OK && !s.Skipped {
+				m.noteHubError(s.Name + ": " + s.Detail)
+				m.log(slog.LevelWarn, "probe failed", "url", msg.Report.URL, "step", s.Name, "err", s.Detail)
+				return
+			}
+		}
+		m.log(slog.LevelInfo, "probe ok", "url", msg.Report.URL)
+	})
+}
+
+// handleHubDialed takes over a client dialed by [r], closing the stale one.
+func (m *Model) handleHubDialed(msg hubDialedMsg) {
+	m.onHub(m.hubIndex(msg.Hub), func() {
+		m.dialing = false
+		if msg.Err != nil {
+			m.noteHubError(msg.Err.Error())
+			m.addLog("WARN", "HUB", "retry failed: "+msg.Err.Error())
+			return
+		}
+		if m.hubConnected() {
+			// The old client got back on its own meanwhile.
+			msg.Client.Close()
+			return
+		}
+		if m.Hub != nil {
+			m.Hub.Close()
+		}
+		m.Hub = msg.Client
+		if m.Dial.Adopt != nil {
+			m.Dial.Adopt(msg.Client)
+		}
+		m.addLog("INFO", "HUB", "connected to "+m.Dial.URL)
+		if m.index == 0 {
+			m.requestGovernorSchedule()
+		}
+	})
+}
+
+// handleDiagKeys handles [c] on the System sheet and the diagnostics view.
+// Returns true if the key was consumed, and the probe or dial to run.
+func (m *Model) handleDiagKeys(key string) (bool, tea.Cmd) {
+	if m.ActiveSheet != types.SheetSystem || m.SystemCommandInput || m.NodeInspect {
+		return false, nil
+	}
+	if !m.SystemDiag {
+		if key != "c" {
+			return false, nil
+		}
+		m.SystemDiag = true
+		m.SystemAllHubs = false
+		return true, m.probeHub()
+	}
+	switch key {
+	case "esc", "c":
+		m.SystemDiag = false
+	case "r":
+		return true, m.retryHub()
+	case "@":
+		if len(m.Hubs) > 1 {
+			m.switchHub((m.index + 1) % len(m.Hubs))
+			return true, m.probeHub()
+		}
+	case "q", "ctrl+c", "1", "2", "3", "4", "5", "6", "7", "tab", "shift+tab":
+		return false, nil
+	}
+	return true, nil
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"strings"
+
+	"monoview/internal/types"
+)
+
+// Dashboard sheet: the widgets of the config's "dashboard" in a grid. Most
+// widgets only show; the devices widgets keep the Home controls ([↑/↓]
+// device, [Enter] toggle, [←/→] adjust) so a wall terminal can switch lights
+// without leaving the sheet.
+
+// dashboardDevices returns the indexes into HomeDevices shown by the devices widgets, in order.
+func (m Model) dashboardDevices() []int {
+	var out []int
+	seen := map[int]bool{}
+	for _, w := range m.Config.Dashboard.Widgets {
+		if w.Type != "devices" {
+			continue
+		}
+		for i, d := range m.HomeDevices {
+			if seen[i] || (w.Node != "" && !strings.EqualFold(d.Node, w.Node)) {
+				continue
+			}
+			seen[i] = true
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// moveDashboardDevice selects the next (delta 1) or previous (-1) dashboard device.
+func (m *Model) moveDashboardDevice(delta int) {
+	devices := m.dashboardDevices()
+	if len(devices) == 0 {
+		return
+	}
+	pos := -1
+	for i, d := range devices {
+		if d == m.SelectedDevice {
+			pos = i
+		}
+	}
+	switch {
+	case pos < 0:
+		pos = 0
+	case pos+delta >= 0 && pos+delta < len(devices):
+		pos += delta
+	}
+	m.SelectedDevice = devices[pos]
+}
+
+// selectedDashboardDevice returns the selected device if a devices widget shows it.
+func (m *Model) selectedDashboardDevice() *types.HomeDevice {
+	for _, i := range m.dashboardDevices() {
+		if i == m.SelectedDevice {
+			return &m.HomeDevices[i]
+		}
+	}
+	return nil
+}
+
+// handleDashboardKeys handles the Dashboard sheet. Returns true if the key was consumed.
+func (m *Model) handleDashboardKeys(key string) bool {
+	if m.ActiveSheet != types.SheetDashboard {
+		return false
+	}
+	switch key {
+	case "j", "down":
+		m.moveDashboardDevice(1)
+	case "k", "up":
+		m.moveDashboardDevice(-1)
+	case "enter", " ":
+		if dev := m.selectedDashboardDevice(); dev != nil {
+			m.deviceAction(dev)
+		}
+	case "h", "left":
+		if dev := m.selectedDashboardDevice(); dev != nil {
+			m.adjustDevice(dev, -m.homeStep())
+		}
+	case "l", "right":
+		if dev := m.selectedDashboardDevice(); dev != nil {
+			m.adjustDevice(dev, m.homeStep())
+		}
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,234 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"monoview/internal/store"
+	"monoview/internal/types"
+)
+
+// Deadlines sheet: done state, sorting, filtering and weekly burn-down.
+
+const deadlineDoneFile = "deadlines.json"
+
+var deadlineSortNames = []string{"due", "title", "status"}
+
+var deadlineFilterNames = []string{"all", "pending", "overdue", "done"}
+
+func loadDeadlineDone() map[string]time.Time {
+	done := map[string]time.Time{}
+	if path, err := store.StatePath(deadlineDoneFile); err == nil {
+		_ = store.Load(path, &done)
+	}
+	return done
+}
+
+func (m *Model) saveDeadlineDone() {
+	if path, err := store.StatePath(deadlineDoneFile); err == nil {
+		_ = store.Save(path, m.DeadlineDone)
+	}
+}
+
+func (m Model) deadlineIsDone(e types.Event) bool {
+	_, ok := m.DeadlineDone[e.ID]
+	return ok && e.ID != ""
+}
+
+// visibleDeadlines returns Deadlines after applying DeadlineFilter and DeadlineSort.
+func (m Model) visibleDeadlines() []types.Event {
+	now := m.LastUpdate
+	var out []types.Event
+	for _, e := range m.Deadlines {
+		done := m.deadlineIsDone(e)
+		switch deadlineFilterNames[m.DeadlineFilter%len(deadlineFilterNames)] {
+		case "pending":
+			if done {
+				continue
+			}
+		case "overdue":
+			if done || e.Date.After(now) {
+				continue
+			}
+		case "done":
+			if !done {
+				continue
+			}
+		}
+		out = append(out, e)
+	}
+
+	less := func(a, b types.Event) bool { return a.Date.Before(b.Date) }
+	switch deadlineSortNames[m.DeadlineSort%len(deadlineSortNames)] {
+	case "title":
+		less = func(a, b types.Event) bool {
+			return strings.ToLower(a.Title) < strings.ToLower(b.Title)
+		}
+	case "status":
+		// Pending first (overdue before upcoming), done last; ties by due date.
+		rank := func(e types.Event) int {
+			switch {
+			case m.deadlineIsDone(e):
+				return 2
+			case !e.Date.After(now):
+				return 0
+			default:
+				return 1
+			}
+		}
+		less = func(a, b types.Event) bool {
+			ra, rb := rank(a), rank(b)
+			if ra != rb {
+				return ra < rb
+			}
+			return a.Date.Before(b.Date)
+		}
+	}
+	for i := 0; i < len(out); i++ {
+		for j := i + 1; j < len(out); j++ {
+			if less(out[j], out[i]) {
+				out[i], out[j] = out[j], out[i]
+			}
+		}
+	}
+	return out
+}
+
+func (m *Model) clampSelectedDeadline() {
+	n := len(m.visibleDeadlines())
+	if m.SelectedDeadline >= n {
+		m.SelectedDeadline = n - 1
+	}
+	if m.SelectedDeadline < 0 {
+		m.SelectedDeadline = 0
+	}
+}
+
+func (m *Model) toggleSelectedDeadlineDone() {
+	list := m.visibleDeadlines()
+	if m.SelectedDeadline < 0 || m.SelectedDeadline >= len(list) {
+		return
+	}
+	id := list[m.SelectedDeadline].ID
+	if id == "" {
+		return
+	}
+	if m.DeadlineDone == nil {
+		m.DeadlineDone = map[string]time.Time{}
+	}
+	if _, ok := m.DeadlineDone[id]; ok {
+		delete(m.DeadlineDone, id)
+	} else {
+		m.DeadlineDone[id] = time.Now()
+	}
+	m.saveDeadlineDone()
+	m.clampSelectedDeadline()
+}
+
+func (m *Model) cycleDeadlineSort() {
+	m.DeadlineSort = (m.DeadlineSort + 1) % len(deadlineSortNames)
+}
+
+func (m *Model) cycleDeadlineFilter() {
+	m.DeadlineFilter = (m.DeadlineFilter + 1) % len(deadlineFilterNames)
+	m.clampSelectedDeadline()
+}
+
+// handleDeadlineKeys handles sheet-specific keys on the Deadlines sheet.
+// Returns true if the key was consumed.
+func (m *Model) handleDeadlineKeys(key string) bool {
+	if m.ActiveSheet != types.SheetDeadlines {
+		return false
+	}
+	switch key {
+	case "j", "down":
+		if m.SelectedDeadline < len(m.visibleDeadlines())-1 {
+			m.SelectedDeadline++
+		}
+	case "k", "up":
+		if m.SelectedDeadline > 0 {
+			m.SelectedDeadline--
+		}
+	case "enter", " ", "x":
+		m.toggleSelectedDeadlineDone()
+	case "s":
+		m.cycleDeadlineSort()
+	case "f":
+		m.cycleDeadlineFilter()
+	default:
+		return false
+	}
+	return true
+}
+
+// burnDownDay is one day of the weekly burn-down: deadlines due this week
+// that were completed vs still outstanding at the end of that day.
+type burnDownDay struct {
+	Day         time.Time
+	Completed   int
+	Outstanding int
+	Future      bool
+}
+
+// weeklyBurnDown covers Monday..Sunday of the week containing now.
+func (m Model) weeklyBurnDown(now time.Time) []burnDownDay {
+	offset := int(now.Weekday()) - 1
+	if offset < 0 {
+		offset = 6
+	}
+	monday := time.Date(now.Year(), now.Month(), now.Day()-offset, 0, 0, 0, 0, now.Location())
+	weekEnd := monday.AddDate(0, 0, 7)
+
+	var week []types.Event
+	for _, e := range m.Deadlines {
+		if !e.Date.Before(monday) && e.Date.Before(weekEnd) {
+			week = append(week, e)
+		}
+	}
+
+	days := make([]burnDownDay, 7)
+	for i := range days {
+		day := monday.AddDate(0, 0, i)
+		endOfDay := day.AddDate(0, 0, 1)
+		completed := 0
+		for _, e := range week {
+			if doneAt, ok := m.DeadlineDone[e.ID]; ok && e.ID != "" && doneAt.Before(endOfDay) {
+				completed++
+			}
+		}
+		days[i] = burnDownDay{
+			Day:         day,
+			Completed:   completed,
+			Outstanding: len(week) - completed,
+			Future:      day.After(now),
+		}
+	}
+	return days
+}
+
+// formatDeadlineCountdown renders time until a deadline: hours and minutes
+// under a day, days and hours otherwise; negative durations are overdue.
+func formatDeadlineCountdown(d time.Duration) string {
+	overdue := d < 0
+	if overdue {
+		d = -d
+	}
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	mins := int(d.Minutes()) % 60
+
+	var s string
+	switch {
+	case days > 0:
+		s = fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		s = fmt.Sprintf("%dh %dm", hours, mins)
+	default:
+		s = fmt.Sprintf("%dm", mins)
+	}
+	if overdue {
+		return "-" + s
+	}
+	return s
+}
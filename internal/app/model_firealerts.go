@@ -0,0 +1,142 @@
+package app
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"monoview/internal/store"
+	"monoview/internal/types"
+)
+
+// Fire alerts: every ALL:FIRE:TIMER/ALARM is queued (FireAlerts, oldest
+// first) and acknowledged one by one; the VERTEX buzzer is turned off only
+// when the last pending alert is dismissed or snoozed. Each fire is also
+// recorded in FiredHistory (persisted, shown on Home as "fired today").
+
+const firedHistoryFile = "fired.json"
+
+// maxFiredHistory caps the persisted history (older days are dropped on load anyway).
+const maxFiredHistory = 100
+
+func loadFiredHistory() []types.FireAlert {
+	var hist []types.FireAlert
+	if path, err := store.StatePath(firedHistoryFile); err == nil {
+		_ = store.Load(path, &hist)
+	}
+	today := startOfDay(time.Now())
+	var kept []types.FireAlert
+	for _, a := range hist {
+		if a.FiredAt.Before(today) {
+			continue
+		}
+		if a.Outcome == "" {
+			a.Outcome = "missed" // still pending when monoview quit
+		}
+		kept = append(kept, a)
+	}
+	return kept
+}
+
+func (m *Model) saveFiredHistory() {
+	if len(m.FiredHistory) > maxFiredHistory {
+		m.FiredHistory = m.FiredHistory[len(m.FiredHistory)-maxFiredHistory:]
+	}
+	if path, err := store.StatePath(firedHistoryFile); err == nil {
+		_ = store.Save(path, m.FiredHistory)
+	}
+}
+
+// pushFireAlert queues a fired job and records it in the history.
+func (m *Model) pushFireAlert(kind, name string) {
+	a := types.FireAlert{JobKind: kind, JobName: name, FiredAt: time.Now()}
+	m.FireAlerts = append(m.FireAlerts, a)
+	m.FiredHistory = append(m.FiredHistory, a)
+	m.saveFiredHistory()
+}
+
+// firedToday returns today's history, newest first.
+func (m Model) firedToday() []types.FireAlert {
+	today := startOfDay(time.Now())
+	var out []types.FireAlert
+	for i := len(m.FiredHistory) - 1; i >= 0; i-- {
+		if m.FiredHistory[i].FiredAt.Before(today) {
+			break
+		}
+		out = append(out, m.FiredHistory[i])
+	}
+	return out
+}
+
+func (m *Model) selectedFireAlert() *types.FireAlert {
+	if m.SelectedFireAlert < 0 || m.SelectedFireAlert >= len(m.FireAlerts) {
+		return nil
+	}
+	return &m.FireAlerts[m.SelectedFireAlert]
+}
+
+// ackFireAlert removes the selected alert, records outcome in the history and
+// turns the buzzer off once nothing is pending.
+func (m *Model) ackFireAlert(outcome string) {
+	a := m.selectedFireAlert()
+	if a == nil {
+		return
+	}
+	for i := range m.FiredHistory {
+		h := &m.FiredHistory[i]
+		if h.JobName == a.JobName && h.FiredAt.Equal(a.FiredAt) {
+			h.Outcome = outcome
+			break
+		}
+	}
+	m.FireAlerts = append(m.FireAlerts[:m.SelectedFireAlert], m.FireAlerts[m.SelectedFireAlert+1:]...)
+	if m.SelectedFireAlert >= len(m.FireAlerts) {
+		m.SelectedFireAlert = len(m.FireAlerts) - 1
+	}
+	if m.SelectedFireAlert < 0 {
+		m.SelectedFireAlert = 0
+	}
+	if len(m.FireAlerts) == 0 {
+		m.HubSend("VERTEX", "OFF", "BUZZ")
+	}
+	m.saveFiredHistory()
+}
+
+func (m *Model) dismissFireAlert() {
+	m.ackFireAlert("dismissed")
+	m.requestAchtungList() // refresh list after dismiss
+}
+
+func (m *Model) dismissAllFireAlerts() {
+	for len(m.FireAlerts) > 0 {
+		m.SelectedFireAlert = 0
+		m.ackFireAlert("dismissed")
+	}
+	m.requestAchtungList()
+}
+
+// handleFireAlertKeys handles the alert stack while alerts are pending. Returns true if the key was consumed.
+func (m *Model) handleFireAlertKeys(msg tea.KeyMsg) bool {
+	if len(m.FireAlerts) == 0 {
+		return false
+	}
+	switch msg.String() {
+	case "ctrl+c":
+		return false
+	case "enter", " ", "q", "esc":
+		m.dismissFireAlert()
+	case "s":
+		m.snoozeFireAlert()
+	case "A":
+		m.dismissAllFireAlerts()
+	case "j", "down":
+		if m.SelectedFireAlert < len(m.FireAlerts)-1 {
+			m.SelectedFireAlert++
+		}
+	case "k", "up":
+		if m.SelectedFireAlert > 0 {
+			m.SelectedFireAlert--
+		}
+	}
+	return true
+}
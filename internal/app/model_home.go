@@ -17,62 +17,67 @@ import (
 func (m *Model) queryDeviceStates() {
 	seen := map[string]bool{}
 	for _, dev := range m.HomeDevices {
-		switch dev.Kind {
-		case "toggle":
-			key := dev.Node + ":" + dev.Topic + ":STATE"
-			if !seen[key] {
-				seen[key] = true
-				m.HubSend(dev.Node, "GET", dev.Topic, "STATE")
-			}
-		case "cycle":
-			key := dev.Node + ":" + dev.Topic + ":MODE"
-			if !seen[key] {
-				seen[key] = true
-				m.HubSend(dev.Node, "GET", dev.Topic, "MODE")
-			}
-		case "value":
-			key := dev.Node + ":" + dev.Topic + ":" + dev.Property
-			if !seen[key] {
-				seen[key] = true
-				m.HubSend(dev.Node, "GET", dev.Topic, dev.Property)
-			}
+		prop := deviceStateProperty(dev)
+		if prop == "" {
+			continue
+		}
+		key := dev.Node + ":" + dev.Topic + ":" + prop
+		if !seen[key] {
+			seen[key] = true
+			m.HubSend(dev.Node, "GET", dev.Topic, prop)
 		}
 	}
 }
 
+// deviceStateProperty is the property GET reads for a device ("" for actions).
+func deviceStateProperty(dev types.HomeDevice) string {
+	switch dev.Kind {
+	case "toggle":
+		return "STATE"
+	case "cycle":
+		return "MODE"
+	case "value":
+		return dev.Property
+	}
+	return ""
+}
+
 func (m *Model) requestAchtungList() {
 	m.HubSend("ACHTUNG", "GET", "LIST")
 	m.LastAchtungSync = time.Now()
 }
 
-func parseAchtungEndTime(kind, remaining, due string) *time.Time {
+// parseAchtungEndTime returns when a job ends: now plus remaining for a
+// TIMER (a duration such as 4m30s, or seconds), due for an ALARM.
+func parseAchtungEndTime(kind, remaining, due string) (time.Time, error) {
 	now := time.Now()
-	kind = strings.ToUpper(kind)
-	if kind == "TIMER" {
+	switch strings.ToUpper(kind) {
+	case "TIMER":
 		var d time.Duration
 		if v, err := time.ParseDuration(remaining); err == nil {
 			d = v
 		} else if sec, err := strconv.ParseInt(remaining, 10, 64); err == nil {
 			d = time.Duration(sec) * time.Second
 		} else {
-			return nil
+			return time.Time{}, fmt.Errorf("remaining %q is neither a duration nor seconds", remaining)
 		}
-		t := now.Add(d)
-		return &t
-	}
-	if kind == "ALARM" {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("remaining %q is negative", remaining)
+		}
+		return now.Add(d), nil
+	case "ALARM":
 		for _, layout := range []string{
 			"2006.01.02:15.04", "2006.01.02:15.04:05",
 			"2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02 15:04:05",
 			"02.01.2006 15:04",
 		} {
 			if t, err := time.ParseInLocation(layout, due, time.Local); err == nil {
-				return &t
+				return t, nil
 			}
 		}
-		return nil
+		return time.Time{}, fmt.Errorf("due %q is not a date and time", due)
 	}
-	return nil
+	return time.Time{}, fmt.Errorf("unknown job kind %q", kind)
 }
 
 func (m *Model) updateJobRemaining(job *types.AchtungJob) {
@@ -106,16 +111,35 @@ func (m *Model) handleAchtungResponse(msg monolink.Message) {
 
 	switch noun {
 	case "LIST":
+		// KIND:NAME pairs.
 		var jobs []types.AchtungJob
+		if len(args)%2 != 0 {
+			m.rejectPayload(msg, false, fmt.Sprintf("odd number of fields: %q has no name", args[len(args)-1]))
+		}
 		for i := 0; i+1 < len(args); i += 2 {
+			kind, name := strings.ToUpper(args[i]), args[i+1]
+			if kind != "TIMER" && kind != "ALARM" {
+				m.rejectPayload(msg, false, fmt.Sprintf("job %q: unknown kind %q", name, args[i]))
+				continue
+			}
+			if name == "" {
+				m.rejectPayload(msg, false, fmt.Sprintf("%s without a name", kind))
+				continue
+			}
 			jobs = append(jobs, types.AchtungJob{
-				Kind:      strings.ToUpper(args[i]),
-				Name:      args[i+1],
+				Kind:      kind,
+				Name:      name,
 				Remaining: "—",
 				Due:       "—",
 			})
 		}
-		m.AchtungJobs = jobs
+		m.carryTimerTotals(jobs)
+		m.AchtungJobs = keepPausedJobs(m.AchtungJobs, jobs)
+		m.markAchtungFresh()
+		m.replayWaitJobs = false
+		if !m.checkReplay() && m.replayBy.IsZero() {
+			m.ensureRecurringArmed()
+		}
 		if m.SelectedAchtungJob >= len(m.AchtungJobs) {
 			if len(m.AchtungJobs) > 0 {
 				m.SelectedAchtungJob = len(m.AchtungJobs) - 1
@@ -124,23 +148,39 @@ func (m *Model) handleAchtungResponse(msg monolink.Message) {
 			}
 		}
 		for _, j := range m.AchtungJobs {
-			m.HubSend("ACHTUNG", "GET", "JOB", j.Name)
+			if !j.Paused {
+				m.HubSend("ACHTUNG", "GET", "JOB", j.Name)
+			}
 		}
 	case "JOB":
 		if len(args) < 4 {
+			m.rejectPayload(msg, true, "want KIND:NAME:REMAINING:DUE")
 			return
 		}
 		kind, name, remaining, due := args[0], args[1], args[2], args[3]
+		if k := strings.ToUpper(kind); k != "TIMER" && k != "ALARM" {
+			m.rejectPayload(msg, true, fmt.Sprintf("unknown job kind %q", kind))
+			return
+		}
 		for i := range m.AchtungJobs {
 			if m.AchtungJobs[i].Name == name {
 				m.AchtungJobs[i].Kind = strings.ToUpper(kind)
 				m.AchtungJobs[i].Due = due
-				m.AchtungJobs[i].EndTime = parseAchtungEndTime(kind, remaining, due)
-				if m.AchtungJobs[i].EndTime == nil {
+				m.AchtungJobs[i].EndTime = nil
+				end, err := parseAchtungEndTime(kind, remaining, due)
+				if err != nil {
+					// Keep the job, showing what ACHTUNG sent.
+					m.rejectPayload(msg, false, err.Error())
 					m.AchtungJobs[i].Remaining = remaining
 				} else {
+					m.AchtungJobs[i].EndTime = &end
+					if m.AchtungJobs[i].Total == 0 && m.AchtungJobs[i].Kind == "TIMER" {
+						// Not started from here: count progress from when we first saw it.
+						m.AchtungJobs[i].Total = time.Until(*m.AchtungJobs[i].EndTime)
+					}
 					m.updateJobRemaining(&m.AchtungJobs[i])
 				}
+				m.stateCacheDirty = true
 				break
 			}
 		}
@@ -155,6 +195,7 @@ func (m *Model) handleAchtungResponse(msg monolink.Message) {
 
 func (m *Model) achtungTimerReset() {
 	m.AchtungTimerMenu = false
+	m.AchtungTimerPicking = false
 	m.AchtungTimerDuration = ""
 	m.AchtungTimerName = ""
 	m.AchtungTimerFocusField = 0
@@ -165,133 +206,133 @@ func (m *Model) achtungAlarmReset() {
 	m.AchtungAlarmDate = ""
 	m.AchtungAlarmTime = ""
 	m.AchtungAlarmName = ""
+	m.AchtungAlarmRepeat = ""
+	m.AchtungAlarmSkipHoli = ""
 	m.AchtungAlarmFocusField = 0
 }
 
-func (m *Model) achtungFormFocusedValue() *string {
-	if m.AchtungTimerMenu {
-		switch m.AchtungTimerFocusField {
-		case 0:
-			return &m.AchtungTimerDuration
-		case 1:
-			return &m.AchtungTimerName
-		}
+// achtungTimerFields describes the timer form: 0=duration, 1=name.
+func (m *Model) achtungTimerFields() []formField {
+	return []formField{
+		{Label: "Duration (e.g. 5m, 1h)", Value: &m.AchtungTimerDuration, Kind: fieldDuration},
+		{Label: "Name (optional)", Value: &m.AchtungTimerName, Kind: fieldText, Optional: true},
 	}
-	if m.AchtungAlarmMenu {
-		switch m.AchtungAlarmFocusField {
-		case 0:
-			return &m.AchtungAlarmDate
-		case 1:
-			return &m.AchtungAlarmTime
-		case 2:
-			return &m.AchtungAlarmName
-		}
+}
+
+// achtungAlarmFields describes the alarm form: 0=date, 1=time, 2=name.
+func (m *Model) achtungAlarmFields() []formField {
+	return []formField{
+		{Label: "Date (YYYY-MM-DD)", Value: &m.AchtungAlarmDate, Kind: fieldDate, Check: checkNotBeforeToday},
+		{Label: "Time (HH:MM)", Value: &m.AchtungAlarmTime, Kind: fieldClock, Check: m.checkAlarmInFuture},
+		{Label: "Name (optional)", Value: &m.AchtungAlarmName, Kind: fieldText, Optional: true},
+		{Label: "Repeat (once/daily/weekdays/mon,fri)", Value: &m.AchtungAlarmRepeat, Kind: fieldText, Optional: true, Check: checkRepeat},
+		{Label: "Skip holidays (y/n)", Value: &m.AchtungAlarmSkipHoli, Kind: fieldText, Optional: true, Check: checkYesNo},
+	}
+}
+
+func checkRepeat(v string) string {
+	if _, _, err := parseRepeat(v); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+func checkYesNo(v string) string {
+	switch strings.ToLower(v) {
+	case "y", "yes", "n", "no":
+		return ""
+	}
+	return "expected y or n"
+}
+
+func checkNotBeforeToday(v string) string {
+	d, err := time.ParseInLocation("2006-01-02", v, time.Local)
+	if err == nil && d.Before(startOfDay(time.Now())) {
+		return "date is in the past"
+	}
+	return ""
+}
+
+// checkAlarmInFuture rejects an alarm time that has already passed on the chosen date.
+func (m *Model) checkAlarmInFuture(v string) string {
+	d, err := time.ParseInLocation("2006-01-02", m.AchtungAlarmDate, time.Local)
+	if err != nil {
+		return ""
+	}
+	t, err := time.Parse("15:04", v)
+	if err != nil {
+		return "" // fieldClock reports it
 	}
-	return &m.AchtungTimerName
+	if _, recurring, _ := parseRepeat(m.AchtungAlarmRepeat); recurring {
+		return "" // first occurrence is the next matching day
+	}
+	at := time.Date(d.Year(), d.Month(), d.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.Local)
+	if !at.After(time.Now()) {
+		return "time has already passed"
+	}
+	return ""
 }
 
 func (m *Model) handleAchtungFormKeys(msg tea.KeyMsg) bool {
-	key := msg.String()
 	if m.AchtungTimerMenu {
-		switch key {
-		case "esc":
-			m.achtungTimerReset()
-			return true
-		case "tab":
-			m.AchtungTimerFocusField = (m.AchtungTimerFocusField + 1) % 2
-			return true
-		case "shift+tab":
-			m.AchtungTimerFocusField = (m.AchtungTimerFocusField + 1) % 2
-			return true
-		case "enter":
-			if m.AchtungTimerFocusField == 1 {
-				m.achtungTimerSubmit()
-				return true
-			}
-			m.AchtungTimerFocusField = (m.AchtungTimerFocusField + 1) % 2
-			return true
-		case "backspace":
-			s := m.achtungFormFocusedValue()
-			runes := []rune(*s)
-			if len(runes) > 0 {
-				*s = string(runes[:len(runes)-1])
-			}
-			return true
-		case " ":
-			*m.achtungFormFocusedValue() += " "
-			return true
+		if m.AchtungTimerPicking {
+			return m.handleTimerPickerKeys(msg)
 		}
-		if msg.Type == tea.KeyRunes && len(msg.Runes) > 0 {
-			*m.achtungFormFocusedValue() += string(msg.Runes)
+		if msg.String() == "esc" {
+			m.achtungTimerReset()
 			return true
 		}
-		return false
+		return handleFormKeys(msg, m.achtungTimerFields(), &m.AchtungTimerFocusField, m.achtungTimerSubmit)
 	}
 	if m.AchtungAlarmMenu {
-		switch key {
-		case "esc":
+		if msg.String() == "esc" {
 			m.achtungAlarmReset()
 			return true
-		case "tab":
-			m.AchtungAlarmFocusField = (m.AchtungAlarmFocusField + 1) % 3
-			return true
-		case "shift+tab":
-			m.AchtungAlarmFocusField = (m.AchtungAlarmFocusField + 2) % 3
-			return true
-		case "enter":
-			if m.AchtungAlarmFocusField == 2 {
-				m.achtungAlarmSubmit()
-				return true
-			}
-			m.AchtungAlarmFocusField = (m.AchtungAlarmFocusField + 1) % 3
-			return true
-		case "backspace":
-			s := m.achtungFormFocusedValue()
-			runes := []rune(*s)
-			if len(runes) > 0 {
-				*s = string(runes[:len(runes)-1])
-			}
-			return true
-		case " ":
-			*m.achtungFormFocusedValue() += " "
-			return true
 		}
-		if msg.Type == tea.KeyRunes && len(msg.Runes) > 0 {
-			*m.achtungFormFocusedValue() += string(msg.Runes)
-			return true
-		}
-		return false
+		return handleFormKeys(msg, m.achtungAlarmFields(), &m.AchtungAlarmFocusField, m.achtungAlarmSubmit)
 	}
 	return false
 }
 
 func (m *Model) achtungTimerSubmit() {
-	dur := strings.TrimSpace(m.AchtungTimerDuration)
-	if parseDuration(dur) < 0 {
+	if bad := rejectedField(m.achtungTimerFields()); bad >= 0 {
+		m.AchtungTimerFocusField = bad
 		return
 	}
+	dur := strings.TrimSpace(m.AchtungTimerDuration)
 	name := strings.TrimSpace(m.AchtungTimerName)
+	m.rememberTimer(name, dur)
 	if name == "" {
 		name = fmt.Sprintf("t_%s_%d", dur, time.Now().Unix())
+	} else if unique := m.uniqueTimerName(name); unique != name {
+		m.addLog("INFO", "ACHTUNG", fmt.Sprintf("timer %s is running, starting %s", name, unique))
+		name = unique
 	}
-	m.HubSend("ACHTUNG", "NEW", "TIMER", name, dur)
+	m.sendOrQueue("ACHTUNG", "NEW", "TIMER", name, dur)
+	m.noteTimerTotal(name, time.Duration(parseDuration(dur))*time.Second)
 	m.requestAchtungList()
 	m.achtungTimerReset()
 }
 
 func (m *Model) achtungAlarmSubmit() {
-	if _, err := time.Parse("2006-01-02", m.AchtungAlarmDate); err != nil {
-		return
-	}
-	if _, err := time.Parse("15:04", m.AchtungAlarmTime); err != nil {
+	if bad := rejectedField(m.achtungAlarmFields()); bad >= 0 {
+		m.AchtungAlarmFocusField = bad
 		return
 	}
 	name := strings.TrimSpace(m.AchtungAlarmName)
 	if name == "" {
 		name = fmt.Sprintf("alarm_%d", time.Now().Unix())
 	}
+	if days, recurring, _ := parseRepeat(m.AchtungAlarmRepeat); recurring {
+		from, _ := time.ParseInLocation("2006-01-02", m.AchtungAlarmDate, time.Local)
+		skip := strings.HasPrefix(strings.ToLower(m.AchtungAlarmSkipHoli), "y")
+		m.addRecurringAlarm(types.RecurringAlarm{Name: name, Time: m.AchtungAlarmTime, Days: days, SkipHolidays: skip}, from)
+		m.requestAchtungList()
+		m.achtungAlarmReset()
+		return
+	}
 	datetime := formatAchtungAlarmDateTime(m.AchtungAlarmDate, m.AchtungAlarmTime)
-	m.HubSend("ACHTUNG", "NEW", "ALARM", name, datetime)
+	m.sendOrQueue("ACHTUNG", "NEW", "ALARM", name, datetime)
 	m.requestAchtungList()
 	m.achtungAlarmReset()
 }
@@ -345,10 +386,7 @@ func (m *Model) handleAchtungKeys(msg tea.KeyMsg) bool {
 			m.HomeFocusAchtung = true
 			m.AchtungViewMenu = false
 			if key == "t" {
-				m.AchtungTimerMenu = true
-				m.AchtungTimerFocusField = 0
-				m.AchtungTimerDuration = ""
-				m.AchtungTimerName = ""
+				m.achtungTimerOpen()
 				return true
 			}
 			if key == "a" {
@@ -363,7 +401,7 @@ func (m *Model) handleAchtungKeys(msg tea.KeyMsg) bool {
 		}
 	}
 
-	if m.HomeFocusAchtung {
+	if m.HomeFocusAchtung && m.ActiveSheet == types.SheetHome {
 		switch key {
 		case "j", "down":
 			if m.SelectedAchtungJob < len(m.AchtungJobs)-1 {
@@ -390,11 +428,23 @@ func (m *Model) handleAchtungKeys(msg tea.KeyMsg) bool {
 			}
 			m.achtungStopSelectedJob()
 			return true
+		case "p":
+			m.achtungTogglePause()
+			return true
+		case "e":
+			m.achtungExtendSelected()
+			return true
+		case "w":
+			m.togglePomodoro()
+			return true
+		case "f":
+			if len(m.AchtungJobs) > 0 {
+				m.AchtungViewMenu = false
+				m.AchtungBigView = true
+			}
+			return true
 		case "t":
-			m.AchtungTimerMenu = true
-			m.AchtungTimerFocusField = 0
-			m.AchtungTimerDuration = ""
-			m.AchtungTimerName = ""
+			m.achtungTimerOpen()
 			return true
 		case "a":
 			m.AchtungAlarmMenu = true
@@ -413,9 +463,18 @@ func (m *Model) achtungStopSelectedJob() {
 	if m.SelectedAchtungJob >= len(m.AchtungJobs) {
 		return
 	}
-	name := m.AchtungJobs[m.SelectedAchtungJob].Name
-	m.HubSend("ACHTUNG", "STOP", strings.ToUpper(m.AchtungJobs[m.SelectedAchtungJob].Kind), name)
-	m.requestAchtungList()
+	job := m.AchtungJobs[m.SelectedAchtungJob]
+	if job.Paused {
+		// Already stopped on ACHTUNG; just forget the local copy.
+		m.AchtungJobs = append(m.AchtungJobs[:m.SelectedAchtungJob], m.AchtungJobs[m.SelectedAchtungJob+1:]...)
+	} else {
+		m.sendOrQueue("ACHTUNG", "STOP", strings.ToUpper(job.Kind), job.Name)
+		m.requestAchtungList()
+	}
+	m.removeRecurringAlarm(job.Name)
+	if m.Pomodoro.Active && job.Name == m.Pomodoro.JobName {
+		m.stopPomodoro()
+	}
 	if m.SelectedAchtungJob >= len(m.AchtungJobs)-1 {
 		m.SelectedAchtungJob--
 	}
@@ -567,30 +626,34 @@ func (m *Model) toggleAction() {
 	if m.ActiveSheet != types.SheetHome || m.SelectedDevice >= len(m.HomeDevices) {
 		return
 	}
-	dev := &m.HomeDevices[m.SelectedDevice]
+	m.deviceAction(&m.HomeDevices[m.SelectedDevice])
+}
+
+// deviceAction sends the device's Enter action: toggle, next mode, set value or trigger.
+func (m *Model) deviceAction(dev *types.HomeDevice) {
 	dev.Pending = true
 
 	switch dev.Kind {
 	case "toggle":
 		if dev.Status == "on" {
-			m.HubSend(dev.Node, "OFF", dev.Topic)
+			m.sendOrQueue(dev.Node, "OFF", dev.Topic)
 		} else {
-			m.HubSend(dev.Node, "ON", dev.Topic)
+			m.sendOrQueue(dev.Node, "ON", dev.Topic)
 		}
 
 	case "cycle":
 		next := nextModeForDevice(dev)
-		m.HubSend(dev.Node, "SET", dev.Topic, "MODE", strings.ToUpper(next))
+		m.sendOrQueue(dev.Node, "SET", dev.Topic, "MODE", strings.ToUpper(next))
 
 	case "value":
-		m.HubSend(dev.Node, "SET", dev.Topic, dev.Property, fmt.Sprintf("%d", dev.Val))
+		m.sendOrQueue(dev.Node, "SET", dev.Topic, dev.Property, fmt.Sprintf("%d", dev.Val))
 
 	case "action":
 		verb := dev.Property
 		if verb == "" {
 			verb = "PRINT"
 		}
-		m.HubSend(dev.Node, verb, dev.Topic)
+		m.sendOrQueue(dev.Node, verb, dev.Topic)
 	}
 }
 
@@ -598,7 +661,11 @@ func (m *Model) adjustValue(delta int) {
 	if m.ActiveSheet != types.SheetHome || m.SelectedDevice >= len(m.HomeDevices) {
 		return
 	}
-	dev := &m.HomeDevices[m.SelectedDevice]
+	m.adjustDevice(&m.HomeDevices[m.SelectedDevice], delta)
+}
+
+// adjustDevice changes a value device by delta within its range and sends it.
+func (m *Model) adjustDevice(dev *types.HomeDevice, delta int) {
 	if dev.Kind != "value" {
 		return
 	}
@@ -610,7 +677,7 @@ func (m *Model) adjustValue(delta int) {
 		dev.Val = dev.Max
 	}
 	dev.Pending = true
-	m.HubSend(dev.Node, "SET", dev.Topic, dev.Property, fmt.Sprintf("%d", dev.Val))
+	m.sendOrQueue(dev.Node, "SET", dev.Topic, dev.Property, fmt.Sprintf("%d", dev.Val))
 }
 
 func nextModeForDevice(dev *types.HomeDevice) string {
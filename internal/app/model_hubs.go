@@ -0,0 +1,232 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/MrZloHex/monolink"
+	"monoview/internal/creds"
+	"monoview/internal/props"
+	"monoview/internal/store"
+	"monoview/internal/types"
+)
+
+// Hubs: monoview can be connected to several concentrators (the config's
+// "hubs"). Each keeps its own nodes, logs, history, properties and outbox in
+// a HubState; the active one is embedded in the Model and shown on the
+// System, Props and Dashboard sheets, [@] switches to the next. Traffic and
+// ticks of the other hubs are handled with their state swapped in (onHub),
+// and commands go to the hub the addressed node is on. [a] on the System
+// sheet lists the nodes of every hub.
+
+// hubStatePath returns the path of a hub's state file; dir is "" for the first hub.
+func hubStatePath(dir, name string) (string, error) {
+	return store.StatePath(filepath.Join(dir, name))
+}
+
+// AddHub registers a concentrator connection (client may be nil if it was
+// unreachable). The first hub keeps the built-in nodes and the top-level
+// state files; the others start with their pinned nodes and learn the rest
+// from traffic.
+func (m *Model) AddHub(name string, client *monolink.Client) {
+	if m.Name == "" && m.Hub == nil && len(m.Hubs) <= 1 {
+		m.Name = name
+		m.Hub = client
+		if len(m.Hubs) == 0 {
+			m.Hubs = make([]HubState, 1)
+		}
+		return
+	}
+	dir := filepath.Join("hubs", strings.ToLower(name))
+	i := len(m.Hubs)
+	m.Hubs = append(m.Hubs, HubState{
+		Name:        name,
+		Hub:         client,
+		index:       i,
+		dir:         dir,
+		NodeHistory: loadNodeHistory(dir),
+		KnownNodes:  loadKnownNodes(dir),
+		Props:       props.New(),
+	})
+	m.onHub(i, m.addPinnedNodes)
+}
+
+// certWarnDays is how close to expiry a client certificate is logged and shown in yellow.
+const certWarnDays = 30
+
+// SetHubCert records the named hub's client certificate and logs a warning
+// when it expires within certWarnDays.
+func (m *Model) SetHubCert(name string, cert creds.Info) {
+	m.onHub(m.hubIndex(name), func() {
+		m.Cert = cert
+		days := cert.DaysLeft(time.Now())
+		switch {
+		case days < 0:
+			m.addLog("ERR", "TLS", fmt.Sprintf("client certificate expired %s", cert.NotAfter.Format("2006-01-02")))
+		case days < certWarnDays:
+			m.addLog("WARN", "TLS", fmt.Sprintf("client certificate expires in %d days (%s)", days, cert.NotAfter.Format("2006-01-02")))
+		}
+	})
+}
+
+// UseHub makes the named hub active. Returns false if there is no such hub.
+func (m *Model) UseHub(name string) bool {
+	for i, h := range m.hubStates() {
+		if strings.EqualFold(h.Name, name) {
+			m.switchHub(i)
+			return true
+		}
+	}
+	return false
+}
+
+// hubStates returns every hub's state, with the live copy for the swapped-in one.
+func (m *Model) hubStates() []HubState {
+	out := make([]HubState, len(m.Hubs))
+	copy(out, m.Hubs)
+	if m.index < len(out) {
+		out[m.index] = m.HubState
+	}
+	return out
+}
+
+func (m *Model) hubIndex(name string) int {
+	for i, h := range m.hubStates() {
+		if h.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// onHub runs f with hub i's state swapped in.
+func (m *Model) onHub(i int, f func()) {
+	if i == m.index || i < 0 || i >= len(m.Hubs) {
+		f()
+		return
+	}
+	prev := m.index
+	m.Hubs[prev] = m.HubState
+	m.HubState = m.Hubs[i]
+	f()
+	m.Hubs[i] = m.HubState
+	m.HubState = m.Hubs[prev]
+}
+
+// eachHub runs f once per hub with its state swapped in.
+func (m *Model) eachHub(f func()) {
+	for i := range m.Hubs {
+		m.onHub(i, f)
+	}
+}
+
+// nodeHub returns the hub that node is on: the current one if it has the
+// node (or nobody has it), else the first other hub that does.
+func (m *Model) nodeHub(node string) int {
+	node = strings.ToUpper(node)
+	if m.nodeIndex(node) >= 0 || !isNodeName(node) {
+		return m.index
+	}
+	for i, h := range m.Hubs {
+		if i == m.index {
+			continue
+		}
+		for _, n := range h.Nodes {
+			if n.Name == node {
+				return i
+			}
+		}
+	}
+	return m.index
+}
+
+// switchHub makes hub i active and resets the selections that index its state.
+func (m *Model) switchHub(i int) {
+	if i == m.index || i < 0 || i >= len(m.Hubs) {
+		return
+	}
+	m.Hubs[m.index] = m.HubState
+	m.HubState = m.Hubs[i]
+	m.SelectedNode = 0
+	m.LogScrollOffset = 0
+	m.NodeInspect = false
+	m.NodeDetailView = false
+	m.SelectedProp = 0
+	m.SelectedOutbox = 0
+}
+
+// hubLabel names the current hub for logs and alerts ("" with a single hub).
+func (m *Model) hubLabel() string {
+	if len(m.Hubs) < 2 {
+		return ""
+	}
+	return m.Name
+}
+
+// hubNode is one row of the all-hubs node list.
+type hubNode struct {
+	Hub  int
+	Name string
+	Node types.SystemNode
+}
+
+// allHubNodes lists the nodes of every hub, hub by hub.
+func (m *Model) allHubNodes() []hubNode {
+	var out []hubNode
+	for i, h := range m.hubStates() {
+		for _, n := range h.Nodes {
+			out = append(out, hubNode{Hub: i, Name: h.Name, Node: n})
+		}
+	}
+	return out
+}
+
+// handleHubKeys handles [@] (next hub) anywhere and the all-hubs list on the
+// System sheet. Returns true if the key was consumed.
+func (m *Model) handleHubKeys(key string) bool {
+	if key == "@" && len(m.Hubs) > 1 {
+		m.switchHub((m.index + 1) % len(m.Hubs))
+		return true
+	}
+	if m.ActiveSheet != types.SheetSystem || m.SystemCommandInput || m.NodeInspect {
+		return false
+	}
+	if key == "a" && len(m.Hubs) > 1 {
+		m.SystemAllHubs = !m.SystemAllHubs
+		m.SelectedHubNode = 0
+		return true
+	}
+	if !m.SystemAllHubs {
+		return false
+	}
+	rows := m.allHubNodes()
+	switch key {
+	case "j", "down":
+		if m.SelectedHubNode < len(rows)-1 {
+			m.SelectedHubNode++
+		}
+	case "k", "up":
+		if m.SelectedHubNode > 0 {
+			m.SelectedHubNode--
+		}
+	case "enter":
+		// Show the node on its own hub.
+		if m.SelectedHubNode < len(rows) {
+			r := rows[m.SelectedHubNode]
+			m.switchHub(r.Hub)
+			if i := m.nodeIndex(r.Node.Name); i >= 0 {
+				m.SelectedNode = i
+			}
+			m.SystemAllHubs = false
+		}
+	case "esc":
+		m.SystemAllHubs = false
+	case "h", "l", "left", "right", "p", "x", "X", "i", "v", "tab", "shift+tab":
+		// Node keys of the grid would act on a node the list does not show.
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,130 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"monoview/internal/types"
+)
+
+// Kiosk mode (--kiosk) is for a wall or hallway terminal: the keyboard only
+// switches sheets ([1-7]) and dismisses fire alerts, [Ctrl+C] quits, and
+// everything else is ignored so nothing can be deleted, toggled or sent by
+// leaning on the keys. Sheets rotate on their own, the header shows a big
+// clock, and the screen is drawn in dark gray during the night hours.
+
+// ParseKioskSheets parses a rotation order of sheet numbers, e.g. "7,1,4".
+func ParseKioskSheets(s string) ([]types.Sheet, error) {
+	var sheets []types.Sheet
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 1 || n > len(types.SheetNames) {
+			return nil, fmt.Errorf("sheet must be 1-%d, got %q", len(types.SheetNames), part)
+		}
+		sheets = append(sheets, types.Sheet(n-1))
+	}
+	if len(sheets) == 0 {
+		return nil, fmt.Errorf("no sheets in %q", s)
+	}
+	return sheets, nil
+}
+
+// ParseNightHours parses "HH:MM-HH:MM" into offsets from midnight; "" means no night.
+func ParseNightHours(s string) (from, to time.Duration, err error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, 0, nil
+	}
+	start, end, ok := strings.Cut(s, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("expected HH:MM-HH:MM, got %q", s)
+	}
+	parse := func(hm string) (time.Duration, error) {
+		t, err := time.Parse("15:04", strings.TrimSpace(hm))
+		if err != nil {
+			return 0, fmt.Errorf("invalid time %q", hm)
+		}
+		return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+	}
+	if from, err = parse(start); err != nil {
+		return 0, 0, err
+	}
+	if to, err = parse(end); err != nil {
+		return 0, 0, err
+	}
+	return from, to, nil
+}
+
+// kioskNight reports whether the kiosk screen should be dimmed now.
+func (m Model) kioskNight() bool {
+	k := m.Kiosk
+	if !k.Enabled || k.NightFrom == k.NightTo {
+		return false
+	}
+	now := m.LastUpdate
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	off := now.Sub(midnight)
+	if k.NightFrom < k.NightTo {
+		return off >= k.NightFrom && off < k.NightTo
+	}
+	return off >= k.NightFrom || off < k.NightTo
+}
+
+// rotateKiosk moves to the next sheet of the rotation once the current one
+// has been shown for Rotate. A pending fire alert holds the rotation.
+func (m *Model) rotateKiosk() {
+	k := m.Kiosk
+	if !k.Enabled || k.Rotate <= 0 || len(k.Sheets) == 0 || len(m.FireAlerts) > 0 {
+		return
+	}
+	now := m.LastUpdate
+	if m.KioskSwitched.IsZero() {
+		m.KioskSwitched = now
+		return
+	}
+	if now.Sub(m.KioskSwitched) < k.Rotate {
+		return
+	}
+	next := k.Sheets[0]
+	for i, s := range k.Sheets {
+		if s == m.ActiveSheet {
+			next = k.Sheets[(i+1)%len(k.Sheets)]
+		}
+	}
+	m.showSheet(next)
+	m.KioskSwitched = now
+}
+
+// handleKioskKeys consumes every key in kiosk mode except [Ctrl+C].
+func (m *Model) handleKioskKeys(msg tea.KeyMsg) bool {
+	if !m.Kiosk.Enabled {
+		return false
+	}
+	key := msg.String()
+	if key == "ctrl+c" {
+		return false
+	}
+	if len(m.FireAlerts) > 0 {
+		// Dismiss one alert at a time; snooze ([s]) would send a NEW and
+		// [A] clears alerts nobody has looked at.
+		switch key {
+		case "enter", "esc", "j", "k", "up", "down":
+			m.handleFireAlertKeys(msg)
+		}
+		return true
+	}
+	if len(key) == 1 && key[0] >= '1' && int(key[0]-'1') < len(types.SheetNames) {
+		m.showSheet(types.Sheet(key[0] - '1'))
+		// Give a sheet picked by hand a full rotation period.
+		m.KioskSwitched = m.LastUpdate
+	}
+	return true
+}
@@ -0,0 +1,273 @@
+package app
+
+import (
+	"strings"
+	"time"
+
+	"github.com/MrZloHex/monolink"
+	"monoview/internal/store"
+	"monoview/internal/types"
+)
+
+// Node discovery: a message from a node that is not on the System sheet adds
+// it as an unconfigured node, and the concentrator is asked for its node list
+// (GET:NODES) on connect. Discovered nodes are probed with PING, falling back
+// to the other known ping nouns until one gets a PONG. [p] pins a node so it
+// is shown from startup, [x] hides it, [X] shows hidden nodes again. What was
+// learned is kept in the XDG state dir.
+
+const (
+	discoveredNodesFile = "discovered.json"
+	concentratorName    = "CONCENTRATOR"
+	// pingNounMisses is how many unanswered probes make a discovered node try the next ping noun.
+	pingNounMisses = 3
+)
+
+// pingNouns are the ping nouns tried on a discovered node, in order.
+var pingNouns = []string{"PING", "PINT"}
+
+func loadKnownNodes(dir string) map[string]*types.KnownNode {
+	known := map[string]*types.KnownNode{}
+	if path, err := hubStatePath(dir, discoveredNodesFile); err == nil {
+		_ = store.Load(path, &known)
+	}
+	return known
+}
+
+func (m *Model) saveKnownNodes() {
+	if path, err := hubStatePath(m.dir, discoveredNodesFile); err == nil {
+		_ = store.Save(path, m.KnownNodes)
+	}
+}
+
+// addPinnedNodes puts pinned discovered nodes on the System sheet at startup.
+func (m *Model) addPinnedNodes() {
+	for name, k := range m.KnownNodes {
+		if k.Pinned && !k.Hidden && m.nodeIndex(name) < 0 {
+			m.Nodes = append(m.Nodes, discoveredNode(name, k))
+		}
+	}
+	m.sortDiscoveredNodes()
+}
+
+func discoveredNode(name string, k *types.KnownNode) types.SystemNode {
+	noun := k.PingNoun
+	if noun == "" {
+		noun = pingNouns[0]
+	}
+	return types.SystemNode{
+		Name:       name,
+		PingNoun:   noun,
+		Status:     "unknown",
+		Uptime:     "—",
+		Since:      time.Now(),
+		Discovered: true,
+		Pinned:     k.Pinned,
+	}
+}
+
+func (m *Model) nodeIndex(name string) int {
+	for i, n := range m.Nodes {
+		if n.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// sortDiscoveredNodes keeps built-in nodes first and discovered ones after them by name.
+func (m *Model) sortDiscoveredNodes() {
+	var selected string
+	if m.SelectedNode < len(m.Nodes) {
+		selected = m.Nodes[m.SelectedNode].Name
+	}
+	for i := 1; i < len(m.Nodes); i++ {
+		for j := i; j > 0; j-- {
+			a, b := m.Nodes[j-1], m.Nodes[j]
+			if !a.Discovered || (b.Discovered && a.Name <= b.Name) {
+				break
+			}
+			m.Nodes[j-1], m.Nodes[j] = b, a
+		}
+	}
+	if i := m.nodeIndex(selected); i >= 0 {
+		m.SelectedNode = i
+	}
+}
+
+// isNodeName reports whether name can be a node: not empty, not a broadcast and not us or the concentrator.
+func isNodeName(name string) bool {
+	switch name {
+	case "", "ALL", "MONOVIEW", concentratorName:
+		return false
+	}
+	return true
+}
+
+// discoverNode adds name as an unconfigured node unless it is already shown or hidden.
+func (m *Model) discoverNode(name string) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if !isNodeName(name) || m.nodeIndex(name) >= 0 {
+		return
+	}
+	if m.KnownNodes == nil {
+		m.KnownNodes = map[string]*types.KnownNode{}
+	}
+	k := m.KnownNodes[name]
+	if k != nil && k.Hidden {
+		return
+	}
+	if k == nil {
+		k = &types.KnownNode{PingNoun: pingNouns[0], FirstSeen: time.Now()}
+		m.KnownNodes[name] = k
+		m.saveKnownNodes()
+		m.addLog("INFO", "SYSTEM", "discovered node "+name)
+	}
+	m.Nodes = append(m.Nodes, discoveredNode(name, k))
+	m.sortDiscoveredNodes()
+}
+
+// requestNodeList asks the concentrator which nodes are connected.
+func (m *Model) requestNodeList() {
+	m.HubSend(concentratorName, "GET", "NODES")
+}
+
+// handleNodeDiscovery learns nodes from any sender and from the concentrator's OK:NODES list.
+func (m *Model) handleNodeDiscovery(msg monolink.Message) {
+	from := strings.ToUpper(msg.From)
+	if from == concentratorName && strings.ToUpper(msg.Verb) == "OK" && strings.ToUpper(msg.Noun) == "NODES" {
+		for _, name := range msg.Args {
+			m.discoverNode(name)
+		}
+		return
+	}
+	m.discoverNode(from)
+}
+
+// learnPingNoun records the noun a discovered node answered PONG to.
+func (m *Model) learnPingNoun(node *types.SystemNode, msg monolink.Message) {
+	k := m.KnownNodes[node.Name]
+	if !node.Discovered || k == nil {
+		return
+	}
+	if noun := strings.ToUpper(msg.Noun); noun != "" && noun != "PONG" {
+		node.PingNoun = noun
+	}
+	if k.Learned && k.PingNoun == node.PingNoun {
+		return
+	}
+	k.PingNoun = node.PingNoun
+	k.Learned = true
+	k.Misses = 0
+	m.saveKnownNodes()
+}
+
+// nextPingNoun moves a discovered node that keeps missing probes to the next candidate noun.
+func (m *Model) nextPingNoun(node *types.SystemNode) {
+	k := m.KnownNodes[node.Name]
+	if !node.Discovered || k == nil || k.Learned {
+		return
+	}
+	k.Misses++
+	if k.Misses < pingNounMisses {
+		return
+	}
+	k.Misses = 0
+	next := 0
+	for i, noun := range pingNouns {
+		if noun == node.PingNoun {
+			next = (i + 1) % len(pingNouns)
+		}
+	}
+	node.PingNoun = pingNouns[next]
+	k.PingNoun = node.PingNoun
+	m.saveKnownNodes()
+}
+
+func (m *Model) selectedDiscoveredNode() *types.SystemNode {
+	if m.SelectedNode >= len(m.Nodes) || !m.Nodes[m.SelectedNode].Discovered {
+		return nil
+	}
+	return &m.Nodes[m.SelectedNode]
+}
+
+// togglePinNode pins or unpins the selected discovered node.
+func (m *Model) togglePinNode() {
+	node := m.selectedDiscoveredNode()
+	if node == nil {
+		return
+	}
+	k := m.KnownNodes[node.Name]
+	if k == nil {
+		return
+	}
+	k.Pinned = !k.Pinned
+	node.Pinned = k.Pinned
+	m.saveKnownNodes()
+}
+
+// hideSelectedNode removes the selected discovered node and ignores it from now on.
+func (m *Model) hideSelectedNode() {
+	node := m.selectedDiscoveredNode()
+	if node == nil {
+		return
+	}
+	if k := m.KnownNodes[node.Name]; k != nil {
+		k.Hidden = true
+		k.Pinned = false
+	}
+	m.saveKnownNodes()
+	m.Nodes = append(m.Nodes[:m.SelectedNode], m.Nodes[m.SelectedNode+1:]...)
+	if m.SelectedNode >= len(m.Nodes) {
+		m.SelectedNode = len(m.Nodes) - 1
+	}
+	if m.SelectedNode < 0 {
+		m.SelectedNode = 0
+	}
+	if len(m.Nodes) == 0 {
+		m.NodeDetailView = false
+	}
+}
+
+// unhideNodes forgets hidden nodes; they come back the next time they are heard from.
+func (m *Model) unhideNodes() {
+	changed := false
+	for _, k := range m.KnownNodes {
+		if k.Hidden {
+			k.Hidden = false
+			changed = true
+		}
+	}
+	if changed {
+		m.saveKnownNodes()
+		m.requestNodeList()
+	}
+}
+
+func (m *Model) hiddenNodeCount() int {
+	n := 0
+	for _, k := range m.KnownNodes {
+		if k.Hidden {
+			n++
+		}
+	}
+	return n
+}
+
+// handleNodeDiscoveryKeys handles pin/hide on the System sheet. Returns true if the key was consumed.
+func (m *Model) handleNodeDiscoveryKeys(key string) bool {
+	if m.ActiveSheet != types.SheetSystem || m.SystemCommandInput || m.SystemFocusLogs {
+		return false
+	}
+	switch key {
+	case "p":
+		m.togglePinNode()
+	case "x":
+		m.hideSelectedNode()
+	case "X":
+		m.unhideNodes()
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,188 @@
+package app
+
+import (
+	"strconv"
+	"time"
+
+	"monoview/internal/store"
+	"monoview/internal/types"
+)
+
+// Node health history: every PING probe is recorded per node (RTT or miss),
+// together with online/offline transitions and reboots (uptime going
+// backwards). The history is kept for a week in the XDG state dir and drives
+// the sparklines on the System sheet and the node detail view ([v]).
+
+const (
+	nodeHistoryFile = "nodes.json"
+	nodeHistoryKeep = 7 * 24 * time.Hour
+	maxNodeEvents   = 200
+)
+
+func loadNodeHistory(dir string) map[string]*types.NodeHistory {
+	hist := map[string]*types.NodeHistory{}
+	if path, err := hubStatePath(dir, nodeHistoryFile); err == nil {
+		_ = store.Load(path, &hist)
+	}
+	return hist
+}
+
+// saveNodeHistory writes the history when it changed; called with the state cache.
+func (m *Model) saveNodeHistory() {
+	if !m.nodeHistoryDirty {
+		return
+	}
+	path, err := hubStatePath(m.dir, nodeHistoryFile)
+	if err != nil {
+		return
+	}
+	if err := store.Save(path, m.NodeHistory); err == nil {
+		m.nodeHistoryDirty = false
+	}
+}
+
+func (m *Model) nodeHistory(name string) *types.NodeHistory {
+	if m.NodeHistory == nil {
+		m.NodeHistory = map[string]*types.NodeHistory{}
+	}
+	h := m.NodeHistory[name]
+	if h == nil {
+		h = &types.NodeHistory{}
+		m.NodeHistory[name] = h
+	}
+	return h
+}
+
+// recordNodeSample appends a probe result and drops samples older than a week.
+func (m *Model) recordNodeSample(name string, at time.Time, rttMs int64) {
+	h := m.nodeHistory(name)
+	h.Samples = append(h.Samples, types.NodeSample{At: at, RTTMs: rttMs})
+	cut := 0
+	for cut < len(h.Samples) && at.Sub(h.Samples[cut].At) > nodeHistoryKeep {
+		cut++
+	}
+	h.Samples = h.Samples[cut:]
+	m.nodeHistoryDirty = true
+}
+
+func (m *Model) recordNodeEvent(name, kind string, at time.Time) {
+	h := m.nodeHistory(name)
+	h.Events = append(h.Events, types.NodeEvent{At: at, Kind: kind})
+	if len(h.Events) > maxNodeEvents {
+		h.Events = h.Events[len(h.Events)-maxNodeEvents:]
+	}
+	m.nodeHistoryDirty = true
+}
+
+// recordNodeUptime notes a reboot when the reported uptime is lower than the last one.
+func (m *Model) recordNodeUptime(name, raw string) {
+	up, ok := parseUptimeDuration(raw)
+	if !ok {
+		return
+	}
+	h := m.nodeHistory(name)
+	if h.LastUptime > 0 && up < h.LastUptime {
+		m.recordNodeEvent(name, "reboot", time.Now().Add(-up))
+		m.alertReboot(name, up)
+	}
+	h.LastUptime = up
+	m.nodeHistoryDirty = true
+}
+
+func parseUptimeDuration(raw string) (time.Duration, bool) {
+	if ms, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return time.Duration(ms) * time.Millisecond, true
+	}
+	if d, err := time.ParseDuration(raw); err == nil {
+		return d, true
+	}
+	return 0, false
+}
+
+// nodeAvailability is the share of answered probes within window, in percent;
+// ok is false when there were no probes in it.
+func nodeAvailability(h *types.NodeHistory, now time.Time, window time.Duration) (float64, bool) {
+	if h == nil {
+		return 0, false
+	}
+	total, up := 0, 0
+	for i := len(h.Samples) - 1; i >= 0; i-- {
+		s := h.Samples[i]
+		if now.Sub(s.At) > window {
+			break
+		}
+		total++
+		if s.RTTMs >= 0 {
+			up++
+		}
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return float64(up) / float64(total) * 100, true
+}
+
+// nodeRTTs returns the last n samples as sparkline values (misses as -1).
+func nodeRTTs(h *types.NodeHistory, n int) []float64 {
+	if h == nil {
+		return nil
+	}
+	samples := h.Samples
+	if len(samples) > n {
+		samples = samples[len(samples)-n:]
+	}
+	out := make([]float64, len(samples))
+	for i, s := range samples {
+		out[i] = float64(s.RTTMs)
+	}
+	return out
+}
+
+// nodeRTTStats returns min/avg/max RTT of answered probes within window.
+func nodeRTTStats(h *types.NodeHistory, now time.Time, window time.Duration) (lo, avg, hi int64, ok bool) {
+	if h == nil {
+		return 0, 0, 0, false
+	}
+	var sum, n int64
+	for i := len(h.Samples) - 1; i >= 0; i-- {
+		s := h.Samples[i]
+		if now.Sub(s.At) > window {
+			break
+		}
+		if s.RTTMs < 0 {
+			continue
+		}
+		if n == 0 || s.RTTMs < lo {
+			lo = s.RTTMs
+		}
+		if s.RTTMs > hi {
+			hi = s.RTTMs
+		}
+		sum += s.RTTMs
+		n++
+	}
+	if n == 0 {
+		return 0, 0, 0, false
+	}
+	return lo, sum / n, hi, true
+}
+
+// handleNodeDetailKeys opens/closes the node detail view on the System sheet. Returns true if the key was consumed.
+func (m *Model) handleNodeDetailKeys(key string) bool {
+	if m.ActiveSheet != types.SheetSystem || m.SystemCommandInput {
+		return false
+	}
+	switch key {
+	case "v":
+		if !m.SystemFocusLogs && len(m.Nodes) > 0 {
+			m.NodeDetailView = !m.NodeDetailView
+		}
+		return true
+	case "esc":
+		if m.NodeDetailView {
+			m.NodeDetailView = false
+			return true
+		}
+	}
+	return false
+}
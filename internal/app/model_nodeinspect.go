@@ -0,0 +1,183 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MrZloHex/monolink"
+	"monoview/internal/props"
+	"monoview/internal/types"
+)
+
+// Node view ([i] on the System sheet): every topic the selected node has
+// reported with its last value, the recent messages to and from it, quick
+// actions for its devices from the Home catalog, and [g] to GET everything
+// known about it at once.
+
+// maxNodeMessages is how many messages per node the node view keeps.
+const maxNodeMessages = 50
+
+// recordNouns are OK replies that carry lists or records rather than a
+// property value (ACHTUNG LIST/JOB, GOVERNOR EVENTS/SCHEDULE, …). They are
+// not stored, so [g] and [r] never GET them back as properties.
+var recordNouns = map[string]bool{
+	"LIST": true, "JOB": true, "EVENTS": true, "EVENT": true,
+	"DEADLINES": true, "SCHEDULE": true, "NODES": true,
+}
+
+// recordNodeValue keeps the value of an OK reply in the property store:
+// OK:LED:MODE:SOLID is VERTEX.LED.MODE = SOLID, OK:UPTIME:123 is VERTEX.UPTIME = 123.
+func (m *Model) recordNodeValue(msg monolink.Message) {
+	from := strings.ToUpper(msg.From)
+	if strings.ToUpper(msg.Verb) != "OK" || !isNodeName(from) || recordNouns[strings.ToUpper(msg.Noun)] {
+		return
+	}
+	if m.Props == nil {
+		m.Props = props.New()
+	}
+	if p, ok := m.Props.SetReply(from, msg.Noun, msg.Args, time.Now()); ok {
+		m.checkPropertyAlerts(p)
+	}
+}
+
+// recordNodeMessage appends a message to the node's recent traffic.
+func (m *Model) recordNodeMessage(node string, out bool, text string) {
+	node = strings.ToUpper(node)
+	if m.nodeIndex(node) < 0 {
+		return
+	}
+	if m.NodeTraffic == nil {
+		m.NodeTraffic = map[string][]types.NodeMessage{}
+	}
+	msgs := append(m.NodeTraffic[node], types.NodeMessage{At: time.Now(), Out: out, Text: text})
+	if len(msgs) > maxNodeMessages {
+		msgs = msgs[len(msgs)-maxNodeMessages:]
+	}
+	m.NodeTraffic[node] = msgs
+}
+
+// nodePaths returns the node's reported properties, sorted.
+func (m Model) nodePaths(node string) []props.Path {
+	if m.Props == nil {
+		return nil
+	}
+	return m.Props.Paths(node)
+}
+
+// propLabel is "TOPIC PROP" (or just "TOPIC") for the node view.
+func propLabel(p props.Path) string {
+	return strings.TrimSpace(p.Topic + " " + p.Prop)
+}
+
+// nodeDevices returns the Home catalog indices of the node's devices.
+func (m Model) nodeDevices(node string) []int {
+	var idx []int
+	for i, d := range m.HomeDevices {
+		if strings.EqualFold(d.Node, node) {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+func (m *Model) selectedInspectDevice() *types.HomeDevice {
+	if m.SelectedNode >= len(m.Nodes) {
+		return nil
+	}
+	devs := m.nodeDevices(m.Nodes[m.SelectedNode].Name)
+	if m.SelectedNodeAction < 0 || m.SelectedNodeAction >= len(devs) {
+		return nil
+	}
+	return &m.HomeDevices[devs[m.SelectedNodeAction]]
+}
+
+// queryNode sends every GET that can be asked of the node: device states,
+// health probes and each topic it has reported before.
+func (m *Model) queryNode(node *types.SystemNode) {
+	seen := map[string]bool{}
+	var queries [][]string
+	add := func(noun string, args ...string) {
+		q := append([]string{strings.ToUpper(noun)}, args...)
+		key := strings.Join(q, ":")
+		if !seen[key] {
+			seen[key] = true
+			queries = append(queries, q)
+		}
+	}
+	for _, i := range m.nodeDevices(node.Name) {
+		dev := m.HomeDevices[i]
+		if prop := deviceStateProperty(dev); prop != "" {
+			add(dev.Topic, strings.ToUpper(prop))
+		}
+	}
+	for _, p := range m.Config.HealthFor(node.Name).Probes {
+		add(p)
+	}
+	for _, p := range m.nodePaths(node.Name) {
+		if p.Prop == "" {
+			add(p.Topic)
+		} else {
+			add(p.Topic, p.Prop)
+		}
+	}
+	for _, q := range queries {
+		m.HubSend(node.Name, "GET", q[0], q[1:]...)
+	}
+	m.addLog("INFO", "SYSTEM", fmt.Sprintf("queried %d topics of %s", len(queries), node.Name))
+}
+
+// handleNodeInspectKeys opens the node view ([i]) and handles it: [j/k] action, [Enter] run it,
+// [←/→] adjust a value, [g] query all, [p] ping, [i]/[Esc] close; [x/X] are ignored while it is open.
+// Returns true if the key was consumed.
+func (m *Model) handleNodeInspectKeys(key string) bool {
+	if m.ActiveSheet != types.SheetSystem || m.SystemCommandInput {
+		return false
+	}
+	if !m.NodeInspect {
+		if key != "i" || m.SystemFocusLogs || len(m.Nodes) == 0 {
+			return false
+		}
+		m.NodeInspect = true
+		m.SelectedNodeAction = 0
+		return true
+	}
+	if m.SelectedNode >= len(m.Nodes) {
+		m.NodeInspect = false
+		return false
+	}
+	node := &m.Nodes[m.SelectedNode]
+	switch key {
+	case "esc", "i":
+		m.NodeInspect = false
+	case "j", "down":
+		if m.SelectedNodeAction < len(m.nodeDevices(node.Name))-1 {
+			m.SelectedNodeAction++
+		}
+	case "k", "up":
+		if m.SelectedNodeAction > 0 {
+			m.SelectedNodeAction--
+		}
+	case "enter", " ":
+		if dev := m.selectedInspectDevice(); dev != nil {
+			m.deviceAction(dev)
+		}
+	case "left", "h", "right", "l":
+		if dev := m.selectedInspectDevice(); dev != nil {
+			step := max(dev.Step, 1)
+			if key == "left" || key == "h" {
+				step = -step
+			}
+			m.adjustDevice(dev, step)
+		}
+	case "g":
+		m.queryNode(node)
+	case "p":
+		m.pingNode(node)
+	case "x", "X":
+		// Hiding or unhiding nodes would change the list behind the view.
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"time"
+
+	"monoview/internal/notify"
+)
+
+// Notifications for things that happen while the terminal may be in the
+// background: ACHTUNG fires, nodes dropping offline and deadlines due today.
+// Routing per event kind comes from the config file (see internal/notify).
+
+// notify sends an event through the configured notifiers and logs failures.
+func (m *Model) notify(kind, title, body string) {
+	if err := m.Notifier.Notify(notify.Event{Kind: kind, Title: title, Body: body}); err != nil {
+		m.addLog("WARN", "NOTIFY", kind+": "+err.Error())
+	}
+}
+
+// notifyDeadlinesToday notifies once per day for each open deadline due today.
+// Called on every tick; deadlineNotified remembers what was already sent.
+func (m *Model) notifyDeadlinesToday() {
+	now := m.LastUpdate
+	if now.IsZero() {
+		now = time.Now()
+	}
+	today := now.Format("2006-01-02")
+	if m.deadlineNotified == nil {
+		m.deadlineNotified = map[string]string{}
+	}
+	for _, d := range m.Deadlines {
+		if d.Date.Format("2006-01-02") != today || m.deadlineIsDone(d) {
+			continue
+		}
+		key := d.ID
+		if key == "" {
+			key = d.Title
+		}
+		if m.deadlineNotified[key] == today {
+			continue
+		}
+		m.deadlineNotified[key] = today
+		m.notify(notify.KindDeadline, "Deadline today", d.Title+" at "+d.Date.Format("15:04"))
+	}
+}
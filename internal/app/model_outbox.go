@@ -0,0 +1,261 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"monoview/internal/types"
+)
+
+// Outbox: user-initiated commands (timers, alarms, events, device changes)
+// issued while the concentrator is unreachable are queued here and replayed
+// in order once the connection returns and the first hub has sent its fresh
+// job list and events, so conflicts are checked against what is there now.
+// Polls (GET/PING) are never queued.
+
+// replayTimeout is how long a replay waits for the fresh job list and events.
+const replayTimeout = 10 * time.Second
+
+func (m *Model) hubConnected() bool {
+	return m.Hub != nil && m.Hub.Connected()
+}
+
+// sendOrQueue sends a user-initiated command now, or queues it while offline.
+// Returns true if the command was queued.
+func (m *Model) sendOrQueue(to, verb, noun string, args ...string) bool {
+	return m.sendOrQueueEntry(types.OutboxEntry{To: to, Verb: verb, Noun: noun, Args: args})
+}
+
+// recreateJob sends or queues NEW for an ACHTUNG job this app stopped or is
+// re-arming itself, see OutboxEntry.Recreate.
+func (m *Model) recreateJob(kind string, args ...string) bool {
+	return m.sendOrQueueEntry(types.OutboxEntry{To: "ACHTUNG", Verb: "NEW", Noun: kind, Args: args, Recreate: true})
+}
+
+func (m *Model) sendOrQueueEntry(e types.OutboxEntry) bool {
+	if i := m.nodeHub(e.To); i != m.index {
+		var queued bool
+		m.onHub(i, func() { queued = m.sendOrQueueEntry(e) })
+		return queued
+	}
+	// While a replay is pending, queue behind it to keep the order.
+	if m.hubConnected() && m.replayBy.IsZero() {
+		m.HubSend(e.To, e.Verb, e.Noun, e.Args...)
+		return false
+	}
+	e.To, e.Verb, e.Noun = strings.ToUpper(e.To), strings.ToUpper(e.Verb), strings.ToUpper(e.Noun)
+	e.Queued = time.Now()
+	m.Outbox = append(m.Outbox, e)
+	m.addLog("INFO", "OUTBOX", "queued "+outboxEntryString(m.Outbox[len(m.Outbox)-1]))
+	return true
+}
+
+func outboxEntryString(e types.OutboxEntry) string {
+	parts := append([]string{e.To, e.Verb, e.Noun}, e.Args...)
+	return strings.Join(parts, ":")
+}
+
+// checkHubConnection notices offline -> online transitions: it refreshes
+// state that may have changed while disconnected and replays the outbox.
+// The first check only records the state (Init already queried everything).
+func (m *Model) checkHubConnection() {
+	connected := m.hubConnected()
+	if !m.hubChecked {
+		m.hubChecked = true
+		m.hubWasConnected = connected
+		return
+	}
+	if connected && !m.hubWasConnected {
+		m.log(slog.LevelInfo, "hub connected", "queued", len(m.Outbox))
+		m.queryDeviceStates()
+		m.requestGovernorEvents()
+		m.requestGovernorDeadlines()
+		m.requestAchtungList()
+		m.requestNodeList()
+		m.startReplay()
+	}
+	if !connected && m.hubWasConnected {
+		m.noteHubError("connection lost")
+		m.log(slog.LevelWarn, "hub connection lost")
+		// Replayed on the next reconnect.
+		m.replayBy = time.Time{}
+	}
+	m.hubWasConnected = connected
+	m.checkReplay()
+}
+
+// startReplay replays the outbox, on the first hub (ACHTUNG, GOVERNOR) once
+// the job list and events asked for on reconnect are in.
+func (m *Model) startReplay() {
+	if len(m.Outbox) == 0 {
+		return
+	}
+	if m.index != 0 {
+		m.replayOutbox()
+		return
+	}
+	m.replayWaitJobs, m.replayWaitEvents = true, true
+	m.replayBy = time.Now().Add(replayTimeout)
+}
+
+// checkReplay runs a pending replay when nothing is awaited any more or the
+// wait timed out. Returns true if it replayed.
+func (m *Model) checkReplay() bool {
+	if m.replayBy.IsZero() || !m.hubConnected() {
+		return false
+	}
+	late := !time.Now().Before(m.replayBy)
+	if !late && (m.replayWaitJobs || m.replayWaitEvents) {
+		return false
+	}
+	if late {
+		m.addLog("WARN", "OUTBOX", "no fresh job list or events from the hub, replaying against the last known ones")
+	}
+	m.replayBy = time.Time{}
+	m.replayWaitJobs, m.replayWaitEvents = false, false
+	m.replayOutbox()
+	// The replay changed the jobs; recurring alarms are armed from the next list.
+	m.requestAchtungList()
+	return true
+}
+
+// replayOutbox sends queued commands in order. Entries that conflict with the
+// current state are dropped and logged as WARN instead of being sent.
+func (m *Model) replayOutbox() {
+	queue := m.Outbox
+	m.Outbox = nil
+	m.SelectedOutbox = 0
+	for i, e := range queue {
+		if reason := m.outboxConflict(e, queue[i+1:]); reason != "" {
+			m.addLog("WARN", "OUTBOX", "dropped "+outboxEntryString(e)+": "+reason)
+			continue
+		}
+		if e.Recreate && e.Noun == "TIMER" && len(e.Args) >= 2 {
+			if left, ok := m.timerLeft(e.Args[0]); ok {
+				e.Args = append([]string{e.Args[0], achtungDurationArg(left)}, e.Args[2:]...)
+			}
+		}
+		m.HubSend(e.To, e.Verb, e.Noun, e.Args...)
+		m.addLog("INFO", "OUTBOX", fmt.Sprintf("replayed %s (queued %s)", outboxEntryString(e), e.Queued.Format("15:04:05")))
+	}
+}
+
+// outboxConflict returns why e should not be replayed, or "" if it is safe.
+// later holds the entries queued after e.
+func (m *Model) outboxConflict(e types.OutboxEntry, later []types.OutboxEntry) string {
+	switch {
+	case e.To == "ACHTUNG" && e.Verb == "NEW" && len(e.Args) >= 1:
+		for _, j := range m.AchtungJobs {
+			if j.Name == e.Args[0] && !e.Recreate {
+				return "a job named " + j.Name + " already exists"
+			}
+		}
+		if left, ok := m.timerLeft(e.Args[0]); ok && e.Recreate && e.Noun == "TIMER" && left <= 0 {
+			return "timer has already run out"
+		}
+		if e.Noun == "ALARM" && len(e.Args) >= 2 {
+			if at, err := parseAchtungEndTime("ALARM", "", e.Args[1]); err == nil && !at.After(time.Now()) {
+				return "alarm time has passed"
+			}
+		}
+	case e.To == "ACHTUNG" && e.Verb == "STOP" && len(e.Args) >= 1:
+		for _, j := range m.AchtungJobs {
+			if j.Name == e.Args[0] {
+				return ""
+			}
+		}
+		return "job no longer exists"
+	case e.To == "GOVERNOR" && e.Verb == "NEW" && e.Noun == "EVENT" && len(e.Args) >= 3:
+		for _, ev := range m.Events {
+			if ev.Title == e.Args[0] && ev.Date.Format("2006.01.02") == e.Args[1] {
+				return "event already exists"
+			}
+		}
+	case e.To == "GOVERNOR" && e.Verb == "STOP" && e.Noun == "EVENT" && len(e.Args) >= 1:
+		for _, ev := range m.Events {
+			if ev.ID == e.Args[0] {
+				return ""
+			}
+		}
+		return "event no longer exists"
+	case e.To != "ACHTUNG" && e.To != "GOVERNOR":
+		// Device state: only the last queued change per property matters.
+		for _, l := range later {
+			if deviceChangeKey(l) == deviceChangeKey(e) {
+				return "superseded by a later change"
+			}
+		}
+	}
+	return ""
+}
+
+// timerLeft returns how long the running local timer name has left, so a
+// resumed or extended timer replayed late still ends when it shows here.
+func (m *Model) timerLeft(name string) (time.Duration, bool) {
+	for _, j := range m.AchtungJobs {
+		if j.Name == name && j.Kind == "TIMER" && !j.Paused && j.EndTime != nil {
+			return time.Until(*j.EndTime), true
+		}
+	}
+	return 0, false
+}
+
+// deviceChangeKey identifies what a queued device command changes: node,
+// topic and property, so SET:LED:MODE and SET:LED:BRIGHT both survive while
+// ON and OFF of the same topic replace each other.
+func deviceChangeKey(e types.OutboxEntry) string {
+	switch {
+	case e.Verb == "ON" || e.Verb == "OFF":
+		return e.To + ":" + e.Noun + ":STATE"
+	case e.Verb == "SET" && len(e.Args) >= 2:
+		return e.To + ":" + e.Noun + ":" + strings.ToUpper(e.Args[0])
+	}
+	return e.To + ":" + e.Verb + ":" + e.Noun
+}
+
+func (m *Model) cancelSelectedOutbox() {
+	if m.SelectedOutbox < 0 || m.SelectedOutbox >= len(m.Outbox) {
+		return
+	}
+	e := m.Outbox[m.SelectedOutbox]
+	m.Outbox = append(m.Outbox[:m.SelectedOutbox], m.Outbox[m.SelectedOutbox+1:]...)
+	m.addLog("INFO", "OUTBOX", "cancelled "+outboxEntryString(e))
+	if m.SelectedOutbox >= len(m.Outbox) {
+		m.SelectedOutbox = len(m.Outbox) - 1
+	}
+	if m.SelectedOutbox < 0 {
+		m.SelectedOutbox = 0
+	}
+}
+
+// handleOutboxKeys handles the outbox review panel ([o]). Returns true if the key was consumed.
+func (m *Model) handleOutboxKeys(msg tea.KeyMsg) bool {
+	if !m.OutboxView {
+		return false
+	}
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return false
+	case "esc", "o":
+		m.OutboxView = false
+	case "j", "down":
+		if m.SelectedOutbox < len(m.Outbox)-1 {
+			m.SelectedOutbox++
+		}
+	case "k", "up":
+		if m.SelectedOutbox > 0 {
+			m.SelectedOutbox--
+		}
+	case "d", "backspace":
+		m.cancelSelectedOutbox()
+	case "D":
+		m.Outbox = nil
+		m.SelectedOutbox = 0
+		m.addLog("INFO", "OUTBOX", "cancelled all queued commands")
+	}
+	return true
+}
@@ -0,0 +1,190 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"monoview/internal/store"
+	"monoview/internal/types"
+)
+
+// Pomodoro mode: a chain of ACHTUNG timers (work, short break, ..., long
+// break). Each FIRE:TIMER of the current phase starts the next one; finished
+// work sessions are counted per day in the XDG state dir.
+
+const pomodoroSessionsFile = "pomodoro.json"
+
+// DefaultPomodoroPlan is 25m work, 5m short break, 15m long break every 4 sessions, no dimming.
+func DefaultPomodoroPlan() types.PomodoroPlan {
+	return types.PomodoroPlan{
+		Work:       25 * time.Minute,
+		ShortBreak: 5 * time.Minute,
+		LongBreak:  15 * time.Minute,
+		LongEvery:  4,
+		DimTo:      -1,
+	}
+}
+
+// ParsePomodoroPlan parses "work/short/long/every", e.g. "25m/5m/15m/4".
+// Trailing parts may be omitted and keep their defaults.
+func ParsePomodoroPlan(s string) (types.PomodoroPlan, error) {
+	plan := DefaultPomodoroPlan()
+	parts := strings.Split(s, "/")
+	if len(parts) > 4 {
+		return plan, fmt.Errorf("expected work/short/long/every, got %q", s)
+	}
+	durs := []*time.Duration{&plan.Work, &plan.ShortBreak, &plan.LongBreak}
+	for i, p := range parts {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if i == 3 {
+			n, err := strconv.Atoi(p)
+			if err != nil || n < 1 {
+				return plan, fmt.Errorf("long break interval must be a positive number, got %q", p)
+			}
+			plan.LongEvery = n
+			continue
+		}
+		d, err := time.ParseDuration(p)
+		if err != nil || d <= 0 {
+			return plan, fmt.Errorf("invalid duration %q", p)
+		}
+		*durs[i] = d
+	}
+	return plan, nil
+}
+
+func loadPomodoroSessions() map[string]int {
+	sessions := map[string]int{}
+	if path, err := store.StatePath(pomodoroSessionsFile); err == nil {
+		_ = store.Load(path, &sessions)
+	}
+	if sessions == nil { // the file held null
+		sessions = map[string]int{}
+	}
+	return sessions
+}
+
+func (m *Model) savePomodoroSessions() {
+	if path, err := store.StatePath(pomodoroSessionsFile); err == nil {
+		_ = store.Save(path, m.PomodoroSessions)
+	}
+}
+
+func (m *Model) pomodoroSessionsToday() int {
+	return m.PomodoroSessions[time.Now().Format("2006-01-02")]
+}
+
+func (m *Model) pomodoroPhaseDuration(phase string) time.Duration {
+	switch phase {
+	case "short":
+		return m.PomodoroPlan.ShortBreak
+	case "long":
+		return m.PomodoroPlan.LongBreak
+	}
+	return m.PomodoroPlan.Work
+}
+
+// startPomodoroPhase creates the ACHTUNG timer for phase and dims/restores the LED.
+// The job name carries the run's start time so a leftover timer from an
+// earlier run is not taken for the current phase.
+func (m *Model) startPomodoroPhase(phase string, cycle int, started time.Time) {
+	d := m.pomodoroPhaseDuration(phase)
+	name := fmt.Sprintf("pomo_%s_%d_%s", phase, cycle, started.Format("150405"))
+	m.Pomodoro = types.Pomodoro{Active: true, Phase: phase, Cycle: cycle, Started: started, JobName: name}
+	m.sendOrQueue("ACHTUNG", "NEW", "TIMER", name, achtungDurationArg(d))
+	end := time.Now().Add(d)
+	m.AchtungJobs = append(m.AchtungJobs, types.AchtungJob{
+		Kind:      "TIMER",
+		Name:      name,
+		Remaining: formatDuration(d),
+		Due:       "—",
+		EndTime:   &end,
+		Total:     d,
+	})
+	m.noteTimerTotal(name, d)
+	if phase == "work" {
+		m.pomodoroRestoreLED()
+	} else {
+		m.pomodoroDimLED()
+	}
+	m.requestAchtungList()
+}
+
+// togglePomodoro starts a run at work session 1, or stops the running one.
+func (m *Model) togglePomodoro() {
+	if !m.Pomodoro.Active {
+		m.startPomodoroPhase("work", 1, time.Now())
+		return
+	}
+	m.sendOrQueue("ACHTUNG", "STOP", "TIMER", m.Pomodoro.JobName)
+	m.stopPomodoro()
+	m.requestAchtungList()
+}
+
+// stopPomodoro ends the run without touching ACHTUNG (the timer is already gone or being stopped).
+func (m *Model) stopPomodoro() {
+	m.Pomodoro = types.Pomodoro{}
+	m.pomodoroRestoreLED()
+}
+
+// advancePomodoro is called when the current phase's timer fires.
+func (m *Model) advancePomodoro() {
+	p := m.Pomodoro
+	if p.Phase != "work" {
+		m.startPomodoroPhase("work", p.Cycle+1, p.Started)
+		return
+	}
+	m.PomodoroSessions[time.Now().Format("2006-01-02")]++
+	m.savePomodoroSessions()
+	next := "short"
+	if every := m.PomodoroPlan.LongEvery; every > 0 && p.Cycle%every == 0 {
+		next = "long"
+	}
+	m.startPomodoroPhase(next, p.Cycle, p.Started)
+}
+
+func (m *Model) pomodoroLED() *types.HomeDevice {
+	for i := range m.HomeDevices {
+		d := &m.HomeDevices[i]
+		if d.Node == "VERTEX" && d.Topic == "LED" && d.Kind == "value" && d.Property == "BRIGHT" {
+			return d
+		}
+	}
+	return nil
+}
+
+func (m *Model) pomodoroDimLED() {
+	led := m.pomodoroLED()
+	if m.PomodoroPlan.DimTo < 0 || led == nil || m.pomodoroBright >= 0 {
+		return
+	}
+	m.pomodoroBright = led.Val
+	led.Val = m.PomodoroPlan.DimTo
+	m.sendOrQueue(led.Node, "SET", led.Topic, led.Property, strconv.Itoa(led.Val))
+}
+
+func (m *Model) pomodoroRestoreLED() {
+	led := m.pomodoroLED()
+	if led == nil || m.pomodoroBright < 0 {
+		return
+	}
+	led.Val = m.pomodoroBright
+	m.pomodoroBright = -1
+	m.sendOrQueue(led.Node, "SET", led.Topic, led.Property, strconv.Itoa(led.Val))
+}
+
+// pomodoroPhaseLabel is the phase name shown in the ACHTUNG panel and fire popup.
+func pomodoroPhaseLabel(phase string) string {
+	switch phase {
+	case "short":
+		return "short break"
+	case "long":
+		return "long break"
+	}
+	return "work"
+}
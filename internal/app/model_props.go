@@ -0,0 +1,258 @@
+package app
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"monoview/internal/props"
+	"monoview/internal/types"
+)
+
+// Properties sheet: a tree of every value nodes have reported (node → topic
+// → property). [←/→] fold and unfold, [r] re-reads the selection with GET,
+// [Enter] on a writable property edits it and sends SET. Writable are the
+// properties of Home devices and the config's "writable" paths.
+
+// propRow is one line of the property tree.
+type propRow struct {
+	Depth int // 0 node, 1 topic, 2 property
+	Path  props.Path
+}
+
+// key is the fold key of a node or topic row.
+func (r propRow) key() string {
+	if r.Depth == 0 {
+		return r.Path.Node
+	}
+	return r.Path.Node + "." + r.Path.Topic
+}
+
+// propRows flattens the tree; folded nodes and topics hide their children.
+// A topic with a value of its own ("" property) shows it on the topic row;
+// Enter edits it when the topic has no properties.
+func (m Model) propRows() []propRow {
+	if m.Props == nil {
+		return nil
+	}
+	var rows []propRow
+	for _, node := range m.Props.Nodes() {
+		rows = append(rows, propRow{Depth: 0, Path: props.Path{Node: node}})
+		if m.PropsCollapsed[node] {
+			continue
+		}
+		for _, topic := range m.Props.Topics(node) {
+			t := propRow{Depth: 1, Path: props.Path{Node: node, Topic: topic}}
+			rows = append(rows, t)
+			if m.PropsCollapsed[t.key()] {
+				continue
+			}
+			for _, prop := range m.Props.Props(node, topic) {
+				if prop != "" {
+					rows = append(rows, propRow{Depth: 2, Path: props.Path{Node: node, Topic: topic, Prop: prop}})
+				}
+			}
+		}
+	}
+	return rows
+}
+
+func (m Model) selectedPropRow() (propRow, bool) {
+	rows := m.propRows()
+	if m.SelectedProp < 0 || m.SelectedProp >= len(rows) {
+		return propRow{}, false
+	}
+	return rows[m.SelectedProp], true
+}
+
+// propValuePath is the path whose value a row shows and edits: the property,
+// or a topic's own value. ok is false for nodes and topics without one.
+func (m Model) propValuePath(r propRow) (props.Path, bool) {
+	switch r.Depth {
+	case 2:
+		return r.Path, true
+	case 1:
+		_, ok := m.Props.Get(r.Path)
+		return r.Path, ok
+	}
+	return props.Path{}, false
+}
+
+// propWritable reports whether SET may be sent for p.
+func (m Model) propWritable(p props.Path) bool {
+	for _, d := range m.HomeDevices {
+		if !strings.EqualFold(d.Node, p.Node) || !strings.EqualFold(d.Topic, p.Topic) {
+			continue
+		}
+		if (d.Kind == "cycle" || d.Kind == "value") && strings.EqualFold(deviceStateProperty(d), p.Prop) {
+			return true
+		}
+	}
+	for _, w := range m.Config.Writable {
+		if pattern, err := props.ParsePath(w); err == nil && p.Match(pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Model) setPropsCollapsed(key string, collapsed bool) {
+	if m.PropsCollapsed == nil {
+		m.PropsCollapsed = map[string]bool{}
+	}
+	m.PropsCollapsed[key] = collapsed
+}
+
+// propHasChildren reports whether a node or topic row can be folded.
+func (m Model) propHasChildren(r propRow) bool {
+	switch r.Depth {
+	case 0:
+		return true
+	case 1:
+		for _, prop := range m.Props.Props(r.Path.Node, r.Path.Topic) {
+			if prop != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// foldSelectedProp folds the selected node/topic, or jumps to the parent row.
+func (m *Model) foldSelectedProp() {
+	r, ok := m.selectedPropRow()
+	if !ok {
+		return
+	}
+	if m.propHasChildren(r) && !m.PropsCollapsed[r.key()] {
+		m.setPropsCollapsed(r.key(), true)
+		return
+	}
+	// Move to the parent row.
+	rows := m.propRows()
+	for i := m.SelectedProp - 1; i >= 0; i-- {
+		if rows[i].Depth < r.Depth {
+			m.SelectedProp = i
+			return
+		}
+	}
+}
+
+func (m *Model) unfoldSelectedProp() {
+	if r, ok := m.selectedPropRow(); ok && r.Depth < 2 {
+		m.setPropsCollapsed(r.key(), false)
+	}
+}
+
+// refreshSelectedProp sends GET for the selected property, topic or every value of a node.
+func (m *Model) refreshSelectedProp() {
+	r, ok := m.selectedPropRow()
+	if !ok {
+		return
+	}
+	paths := []props.Path{r.Path}
+	if r.Depth == 0 {
+		paths = m.Props.Paths(r.Path.Node)
+	}
+	for _, p := range paths {
+		if p.Prop == "" {
+			m.HubSend(p.Node, "GET", p.Topic)
+		} else {
+			m.HubSend(p.Node, "GET", p.Topic, p.Prop)
+		}
+	}
+}
+
+// selectProp is Enter: fold/unfold a node or topic with properties, edit a value.
+func (m *Model) selectProp() {
+	r, ok := m.selectedPropRow()
+	if !ok {
+		return
+	}
+	if m.propHasChildren(r) {
+		m.setPropsCollapsed(r.key(), !m.PropsCollapsed[r.key()])
+		return
+	}
+	p, ok := m.propValuePath(r)
+	if !ok {
+		return
+	}
+	if !m.propWritable(p) {
+		m.addLog("WARN", "PROPS", p.String()+" is read-only (add it to \"writable\" in the config)")
+		return
+	}
+	v, _ := m.Props.Get(p)
+	m.PropEditing = true
+	m.PropEditBuffer = v.Value
+}
+
+// submitPropEdit sends SET with the edited value and reads it back.
+func (m *Model) submitPropEdit() {
+	m.PropEditing = false
+	r, ok := m.selectedPropRow()
+	if !ok {
+		return
+	}
+	p, ok := m.propValuePath(r)
+	value := strings.TrimSpace(m.PropEditBuffer)
+	if !ok || value == "" {
+		return
+	}
+	if p.Prop == "" {
+		m.sendOrQueue(p.Node, "SET", p.Topic, value)
+		m.HubSend(p.Node, "GET", p.Topic)
+	} else {
+		m.sendOrQueue(p.Node, "SET", p.Topic, p.Prop, value)
+		m.HubSend(p.Node, "GET", p.Topic, p.Prop)
+	}
+}
+
+// handlePropEditKeys handles typing a property value. Returns true if the key was consumed.
+func (m *Model) handlePropEditKeys(msg tea.KeyMsg) bool {
+	if !m.PropEditing {
+		return false
+	}
+	switch {
+	case msg.String() == "ctrl+c":
+		return false
+	case msg.String() == "enter":
+		m.submitPropEdit()
+	case msg.String() == "esc":
+		m.PropEditing = false
+	case msg.String() == "backspace":
+		if runes := []rune(m.PropEditBuffer); len(runes) > 0 {
+			m.PropEditBuffer = string(runes[:len(runes)-1])
+		}
+	case msg.Type == tea.KeyRunes && len(msg.Runes) > 0:
+		m.PropEditBuffer += string(msg.Runes)
+	}
+	return true
+}
+
+// handlePropsKeys handles the Properties sheet. Returns true if the key was consumed.
+func (m *Model) handlePropsKeys(key string) bool {
+	if m.ActiveSheet != types.SheetProps {
+		return false
+	}
+	switch key {
+	case "j", "down":
+		if m.SelectedProp < len(m.propRows())-1 {
+			m.SelectedProp++
+		}
+	case "k", "up":
+		if m.SelectedProp > 0 {
+			m.SelectedProp--
+		}
+	case "h", "left":
+		m.foldSelectedProp()
+	case "l", "right":
+		m.unfoldSelectedProp()
+	case "enter", " ":
+		m.selectProp()
+	case "r":
+		m.refreshSelectedProp()
+	default:
+		return false
+	}
+	return true
+}
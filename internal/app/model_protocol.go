@@ -0,0 +1,57 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MrZloHex/monolink"
+	"monoview/internal/types"
+)
+
+// Protocol errors: a payload from the hub that does not parse is not skipped
+// silently. It is recorded with the raw frame and the reason, counted per
+// node (node panel, node view) and logged in the System log pane — WARN when
+// one item of a frame is dropped, ERR when the whole frame is.
+
+// maxProtoErrors is how many rejected payloads are kept per hub.
+const maxProtoErrors = 100
+
+// rejectPayload records a rejected payload of msg. whole tells whether the
+// whole frame was dropped or just one item of it.
+func (m *Model) rejectPayload(msg monolink.Message, whole bool, reason string) {
+	node := strings.ToUpper(msg.From)
+	e := types.ProtocolError{At: time.Now(), Node: node, Raw: msg.Raw, Reason: reason, Whole: whole}
+	m.ProtoErrors = append(m.ProtoErrors, e)
+	if len(m.ProtoErrors) > maxProtoErrors {
+		m.ProtoErrors = m.ProtoErrors[len(m.ProtoErrors)-maxProtoErrors:]
+	}
+	if m.ProtoErrorCount == nil {
+		m.ProtoErrorCount = map[string]int{}
+	}
+	m.ProtoErrorCount[node]++
+
+	level, what := "WARN", "dropped part of"
+	if whole {
+		level, what = "ERR", "rejected"
+	}
+	m.addLog(level, node, fmt.Sprintf("%s %s: %s", what, msg.Raw, reason))
+}
+
+// rejectItems records the items a parser dropped from msg.
+func (m *Model) rejectItems(msg monolink.Message, errs []error) {
+	for _, err := range errs {
+		m.rejectPayload(msg, false, err.Error())
+	}
+}
+
+// nodeProtoErrors returns the node's rejected payloads, oldest first.
+func (m Model) nodeProtoErrors(node string) []types.ProtocolError {
+	var out []types.ProtocolError
+	for _, e := range m.ProtoErrors {
+		if e.Node == node {
+			out = append(out, e)
+		}
+	}
+	return out
+}
@@ -0,0 +1,291 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Quick add: one prompt ([+]) that parses free text into a GOVERNOR event or
+// an ACHTUNG timer/alarm, e.g.
+//
+//	dentist tomorrow 14:30 @clinic   -> GOVERNOR:NEW:EVENT
+//	timer 25m pomodoro               -> ACHTUNG:NEW:TIMER
+//	alarm mon 07:15 gym              -> ACHTUNG:NEW:ALARM
+
+// quickAdd is the parsed form of a quick-add line.
+type quickAdd struct {
+	Kind     string // "EVENT", "TIMER" or "ALARM"
+	Title    string // event title, timer/alarm name (may be empty for timer/alarm)
+	Location string // event only
+	At       time.Time
+	Duration string // timer only, as typed (e.g. "25m")
+}
+
+func parseQuickAdd(input string, now time.Time) (quickAdd, error) {
+	fields := strings.Fields(input)
+	if len(fields) == 0 {
+		return quickAdd{}, fmt.Errorf("empty")
+	}
+	switch strings.ToLower(fields[0]) {
+	case "timer", "t":
+		return parseQuickAddTimer(fields[1:])
+	case "alarm":
+		return parseQuickAddAlarm(fields[1:], now)
+	default:
+		return parseQuickAddEvent(fields, now)
+	}
+}
+
+func parseQuickAddTimer(fields []string) (quickAdd, error) {
+	q := quickAdd{Kind: "TIMER"}
+	var name []string
+	for _, f := range fields {
+		if q.Duration == "" && parseDuration(f) > 0 {
+			q.Duration = f
+			continue
+		}
+		name = append(name, f)
+	}
+	if q.Duration == "" {
+		return q, fmt.Errorf("missing duration (e.g. 25m)")
+	}
+	q.Title = strings.Join(name, " ")
+	return q, nil
+}
+
+func parseQuickAddAlarm(fields []string, now time.Time) (quickAdd, error) {
+	q := quickAdd{Kind: "ALARM"}
+	day, clock, rest := splitQuickAddWhen(fields, now)
+	if clock < 0 {
+		return q, fmt.Errorf("missing time (HH:MM)")
+	}
+	if day.IsZero() {
+		// No date: next occurrence of the time (today, else tomorrow).
+		day = startOfDay(now)
+		if !now.Before(day.Add(clock)) {
+			day = day.AddDate(0, 0, 1)
+		}
+	} else if isWeekdayWord(fields, now) && !now.Before(day.Add(clock)) {
+		// "mon 07:15" on a Monday after 07:15 means next Monday.
+		day = day.AddDate(0, 0, 7)
+	}
+	q.At = day.Add(clock)
+	if !q.At.After(now) {
+		return q, fmt.Errorf("%s is in the past", q.At.Format("2006-01-02 15:04"))
+	}
+	q.Title = strings.Join(rest, " ")
+	return q, nil
+}
+
+func parseQuickAddEvent(fields []string, now time.Time) (quickAdd, error) {
+	q := quickAdd{Kind: "EVENT"}
+	// Everything from the first @word on is the location.
+	for i, f := range fields {
+		if strings.HasPrefix(f, "@") {
+			q.Location = strings.TrimPrefix(strings.Join(fields[i:], " "), "@")
+			fields = fields[:i]
+			break
+		}
+	}
+	day, clock, rest := splitQuickAddWhen(fields, now)
+	if clock < 0 {
+		return q, fmt.Errorf("missing time (HH:MM)")
+	}
+	if day.IsZero() {
+		day = startOfDay(now)
+	}
+	q.At = day.Add(clock)
+	q.Title = strings.Join(rest, " ")
+	if q.Title == "" {
+		return q, fmt.Errorf("missing title")
+	}
+	return q, nil
+}
+
+// splitQuickAddWhen pulls the first date word and the first HH:MM out of fields.
+// day is zero when no date was given; clock is -1 when no time was given.
+func splitQuickAddWhen(fields []string, now time.Time) (day time.Time, clock time.Duration, rest []string) {
+	clock = -1
+	for _, f := range fields {
+		if clock < 0 {
+			if c, ok := parseQuickAddClock(f); ok {
+				clock = c
+				continue
+			}
+		}
+		if day.IsZero() {
+			if d, ok := parseQuickAddDay(f, now); ok {
+				day = d
+				continue
+			}
+		}
+		rest = append(rest, f)
+	}
+	return day, clock, rest
+}
+
+func parseQuickAddClock(s string) (time.Duration, bool) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, true
+}
+
+// parseQuickAddDay understands today, tomorrow, weekday names (next occurrence,
+// today included), YYYY-MM-DD, DD.MM and DD.MM.YYYY.
+func parseQuickAddDay(s string, now time.Time) (time.Time, bool) {
+	today := startOfDay(now)
+	lower := strings.ToLower(s)
+	switch lower {
+	case "today":
+		return today, true
+	case "tomorrow", "tmr":
+		return today.AddDate(0, 0, 1), true
+	}
+	if wd, ok := parseQuickAddWeekday(lower); ok {
+		ahead := (int(wd) - int(today.Weekday()) + 7) % 7
+		return today.AddDate(0, 0, ahead), true
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return t, true
+	}
+	if t, err := time.ParseInLocation("02.01.2006", s, now.Location()); err == nil {
+		return t, true
+	}
+	if parts := strings.Split(s, "."); len(parts) == 2 {
+		d, errD := strconv.Atoi(parts[0])
+		mo, errM := strconv.Atoi(parts[1])
+		if errD == nil && errM == nil && mo >= 1 && mo <= 12 && d >= 1 && d <= 31 {
+			t := time.Date(now.Year(), time.Month(mo), d, 0, 0, 0, 0, now.Location())
+			if t.Day() == d {
+				if t.Before(today) {
+					t = t.AddDate(1, 0, 0)
+				}
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// isWeekdayWord reports whether the date word in fields was a weekday name.
+func isWeekdayWord(fields []string, now time.Time) bool {
+	for _, f := range fields {
+		if _, ok := parseQuickAddDay(f, now); ok {
+			_, weekday := parseQuickAddWeekday(f)
+			return weekday
+		}
+	}
+	return false
+}
+
+// parseQuickAddWeekday accepts full or three-letter English weekday names.
+func parseQuickAddWeekday(s string) (time.Weekday, bool) {
+	lower := strings.ToLower(s)
+	if len(lower) < 3 {
+		return 0, false
+	}
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		name := strings.ToLower(wd.String())
+		if lower == name || lower == name[:3] {
+			return wd, true
+		}
+	}
+	return 0, false
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// preview describes what will be sent, for the prompt line.
+func (q quickAdd) preview() string {
+	switch q.Kind {
+	case "TIMER":
+		name := q.Title
+		if name == "" {
+			name = "(auto)"
+		}
+		return fmt.Sprintf("TIMER %s  %s", q.Duration, name)
+	case "ALARM":
+		name := q.Title
+		if name == "" {
+			name = "(auto)"
+		}
+		return fmt.Sprintf("ALARM %s  %s", q.At.Format("Mon 02 Jan 15:04"), name)
+	default:
+		s := fmt.Sprintf("EVENT %s  %s", q.At.Format("Mon 02 Jan 15:04"), q.Title)
+		if q.Location != "" {
+			s += "  @" + q.Location
+		}
+		return s
+	}
+}
+
+func (m *Model) quickAddSubmit(q quickAdd) {
+	switch q.Kind {
+	case "TIMER":
+		name := q.Title
+		if name == "" {
+			name = fmt.Sprintf("t_%s_%d", q.Duration, time.Now().Unix())
+		}
+		m.sendOrQueue("ACHTUNG", "NEW", "TIMER", name, q.Duration)
+		m.noteTimerTotal(name, time.Duration(parseDuration(q.Duration))*time.Second)
+		m.requestAchtungList()
+	case "ALARM":
+		name := q.Title
+		if name == "" {
+			name = fmt.Sprintf("alarm_%d", time.Now().Unix())
+		}
+		datetime := formatAchtungAlarmDateTime(q.At.Format("2006-01-02"), q.At.Format("15:04"))
+		m.sendOrQueue("ACHTUNG", "NEW", "ALARM", name, datetime)
+		m.requestAchtungList()
+	case "EVENT":
+		args := []string{q.Title, q.At.Format("2006.01.02"), q.At.Format("15.04")}
+		if q.Location != "" {
+			args = append(args, q.Location)
+		}
+		m.sendOrQueue("GOVERNOR", "NEW", "EVENT", args...)
+		m.requestGovernorEvents()
+	}
+}
+
+// handleQuickAddKeys processes input while the quick-add prompt is open.
+// Returns true if the key was consumed.
+func (m *Model) handleQuickAddKeys(msg tea.KeyMsg) bool {
+	if !m.QuickAddInput {
+		return false
+	}
+	switch msg.String() {
+	case "ctrl+c":
+		return false
+	case "esc":
+		m.QuickAddInput = false
+		m.QuickAddBuffer = ""
+	case "enter":
+		q, err := parseQuickAdd(m.QuickAddBuffer, time.Now())
+		if err != nil {
+			logger.Info("quick add rejected", "input", m.QuickAddBuffer, "err", err)
+			return true
+		}
+		m.quickAddSubmit(q)
+		m.QuickAddInput = false
+		m.QuickAddBuffer = ""
+	case "backspace":
+		if runes := []rune(m.QuickAddBuffer); len(runes) > 0 {
+			m.QuickAddBuffer = string(runes[:len(runes)-1])
+		}
+	case " ":
+		m.QuickAddBuffer += " "
+	default:
+		if msg.Type == tea.KeyRunes && len(msg.Runes) > 0 {
+			m.QuickAddBuffer += string(msg.Runes)
+		}
+	}
+	return true
+}
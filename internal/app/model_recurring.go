@@ -0,0 +1,197 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"monoview/internal/store"
+	"monoview/internal/types"
+)
+
+// Recurring alarms: ACHTUNG only knows one-shot alarms, so monoview keeps the
+// definitions (persisted in the XDG state dir) and arms the next occurrence
+// as a regular NEW:ALARM, re-arming after each FIRE:ALARM.
+
+const recurringAlarmsFile = "alarms.json"
+
+// rearmGuard avoids arming the same occurrence twice when a LIST reply races
+// with the NEW:ALARM we just sent.
+const rearmGuard = 30 * time.Second
+
+func loadRecurringAlarms() []types.RecurringAlarm {
+	var alarms []types.RecurringAlarm
+	if path, err := store.StatePath(recurringAlarmsFile); err == nil {
+		_ = store.Load(path, &alarms)
+	}
+	return alarms
+}
+
+func (m *Model) saveRecurringAlarms() {
+	if path, err := store.StatePath(recurringAlarmsFile); err == nil {
+		_ = store.Save(path, m.RecurringAlarms)
+	}
+}
+
+// parseRepeat parses the alarm form's Repeat field: "" or "once" (one-shot),
+// "daily", "weekdays", "weekends", or a list of weekdays ("mon,wed,fri").
+func parseRepeat(s string) (days []time.Weekday, recurring bool, err error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "", "once":
+		return nil, false, nil
+	case "daily", "every day", "everyday":
+		return []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}, true, nil
+	case "weekdays":
+		return []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}, true, nil
+	case "weekends":
+		return []time.Weekday{time.Saturday, time.Sunday}, true, nil
+	}
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' }) {
+		wd, ok := parseQuickAddWeekday(part)
+		if !ok {
+			return nil, false, fmt.Errorf("unknown day %q", part)
+		}
+		days = append(days, wd)
+	}
+	return days, true, nil
+}
+
+// repeatLabel is the short human form of a day set.
+func repeatLabel(days []time.Weekday) string {
+	set := map[time.Weekday]bool{}
+	for _, d := range days {
+		set[d] = true
+	}
+	weekdays := set[time.Monday] && set[time.Tuesday] && set[time.Wednesday] && set[time.Thursday] && set[time.Friday]
+	weekend := set[time.Saturday] && set[time.Sunday]
+	switch {
+	case len(set) == 7:
+		return "daily"
+	case len(set) == 5 && weekdays:
+		return "weekdays"
+	case len(set) == 2 && weekend:
+		return "weekends"
+	}
+	var names []string
+	for wd := time.Monday; ; wd = (wd + 1) % 7 {
+		if set[wd] {
+			names = append(names, wd.String()[:3])
+		}
+		if wd == time.Sunday {
+			break
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// isHoliday reports whether a GOVERNOR event marked as holiday falls on day.
+func (m *Model) isHoliday(day time.Time) bool {
+	for _, e := range m.Events {
+		if e.Category == "holiday" && e.Date.Year() == day.Year() && e.Date.YearDay() == day.YearDay() {
+			return true
+		}
+	}
+	return false
+}
+
+// nextRecurringOccurrence returns the first occurrence of r strictly after after.
+func (m *Model) nextRecurringOccurrence(r types.RecurringAlarm, after time.Time) (time.Time, bool) {
+	clock, err := time.Parse("15:04", r.Time)
+	if err != nil || len(r.Days) == 0 {
+		return time.Time{}, false
+	}
+	day := startOfDay(after)
+	for i := 0; i < 366; i++ {
+		d := day.AddDate(0, 0, i)
+		match := false
+		for _, wd := range r.Days {
+			if d.Weekday() == wd {
+				match = true
+				break
+			}
+		}
+		if !match || (r.SkipHolidays && m.isHoliday(d)) {
+			continue
+		}
+		at := time.Date(d.Year(), d.Month(), d.Day(), clock.Hour(), clock.Minute(), 0, 0, d.Location())
+		if at.After(after) {
+			return at, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// armRecurringAlarm sends NEW:ALARM for the next occurrence after after.
+func (m *Model) armRecurringAlarm(r *types.RecurringAlarm, after time.Time) {
+	next, ok := m.nextRecurringOccurrence(*r, after)
+	if !ok {
+		return
+	}
+	m.sendOrQueue("ACHTUNG", "NEW", "ALARM", r.Name, formatAchtungAlarmDateTime(next.Format("2006-01-02"), next.Format("15:04")))
+	r.NextAt = next
+	r.ArmedAt = time.Now()
+	m.saveRecurringAlarms()
+}
+
+// addRecurringAlarm stores a new definition (replacing one with the same name) and arms it.
+func (m *Model) addRecurringAlarm(r types.RecurringAlarm, from time.Time) {
+	for i := range m.RecurringAlarms {
+		if m.RecurringAlarms[i].Name == r.Name {
+			m.RecurringAlarms = append(m.RecurringAlarms[:i], m.RecurringAlarms[i+1:]...)
+			break
+		}
+	}
+	m.RecurringAlarms = append(m.RecurringAlarms, r)
+	after := time.Now()
+	if from.After(after) {
+		after = from.Add(-time.Second)
+	}
+	m.armRecurringAlarm(&m.RecurringAlarms[len(m.RecurringAlarms)-1], after)
+}
+
+func (m *Model) recurringAlarm(name string) *types.RecurringAlarm {
+	for i := range m.RecurringAlarms {
+		if m.RecurringAlarms[i].Name == name {
+			return &m.RecurringAlarms[i]
+		}
+	}
+	return nil
+}
+
+func (m *Model) removeRecurringAlarm(name string) {
+	for i := range m.RecurringAlarms {
+		if m.RecurringAlarms[i].Name == name {
+			m.RecurringAlarms = append(m.RecurringAlarms[:i], m.RecurringAlarms[i+1:]...)
+			m.saveRecurringAlarms()
+			return
+		}
+	}
+}
+
+// rearmAfterFire arms the following occurrence when a recurring alarm fires.
+func (m *Model) rearmAfterFire(name string) {
+	if r := m.recurringAlarm(name); r != nil {
+		m.armRecurringAlarm(r, time.Now())
+	}
+}
+
+// ensureRecurringArmed re-arms definitions that ACHTUNG does not currently
+// hold (e.g. missed while monoview or the node was down). Called on LIST.
+func (m *Model) ensureRecurringArmed() {
+	now := time.Now()
+	for i := range m.RecurringAlarms {
+		r := &m.RecurringAlarms[i]
+		armed := false
+		for _, j := range m.AchtungJobs {
+			if j.Name == r.Name {
+				armed = true
+				break
+			}
+		}
+		if armed || now.Sub(r.ArmedAt) < rearmGuard {
+			continue
+		}
+		m.armRecurringAlarm(r, now)
+	}
+}
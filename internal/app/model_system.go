@@ -2,13 +2,15 @@ package app
 
 import (
 	"fmt"
-	"strconv"
+	"slices"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/MrZloHex/monolink"
+	"monoview/internal/config"
+	"monoview/internal/notify"
 	"monoview/internal/types"
 )
 
@@ -81,16 +83,21 @@ func (m *Model) handleFireAlert(msg monolink.Message) {
 	if len(msg.Args) < 1 {
 		return
 	}
-	m.FireAlert = types.FireAlert{Show: true, JobKind: noun, JobName: msg.Args[0]}
+	m.pushFireAlert(noun, msg.Args[0])
+	if noun == "ALARM" {
+		m.notify(notify.KindAlarm, "Alarm fired", msg.Args[0])
+	} else {
+		m.notify(notify.KindTimer, "Timer fired", msg.Args[0])
+	}
+	if noun == "ALARM" {
+		m.rearmAfterFire(msg.Args[0])
+	}
+	if noun == "TIMER" && m.Pomodoro.Active && msg.Args[0] == m.Pomodoro.JobName {
+		m.advancePomodoro()
+	}
 	m.requestAchtungList() // refresh list (fired job is removed)
 }
 
-func (m *Model) dismissFireAlert() {
-	m.HubSend("VERTEX", "OFF", "BUZZ")
-	m.FireAlert.Show = false
-	m.requestAchtungList() // refresh list after dismiss
-}
-
 // systemNodeGridUp/Down/Left/Right move SelectedNode in a 2-column grid.
 // Col 0 = indices 0..mid-1, Col 1 = mid..n-1. Arrows: up/down within column, left/right between columns.
 func (m *Model) systemNodeGridUp() {
@@ -169,26 +176,92 @@ func (m *Model) selectedNodeIsAchtung() bool {
 	return strings.ToUpper(m.Nodes[m.SelectedNode].Name) == "ACHTUNG"
 }
 
+// pollNodes expires unanswered PINGs and probes every node whose interval is up
+// (see config.HealthCheck). Nothing is sent while the hub is down.
 func (m *Model) pollNodes() {
 	now := m.LastUpdate
 	for i := range m.Nodes {
 		node := &m.Nodes[i]
+		hc := m.Config.HealthFor(node.Name)
+		m.expirePings(node, hc, now)
+		m.checkNodeAlerts(node)
+		if m.hubConnected() && (node.PingSent.IsZero() || now.Sub(node.PingSent) >= hc.Interval) {
+			m.pingNode(node)
+		}
+	}
+}
 
-		if !node.LastSeen.IsZero() && now.Sub(node.LastSeen) > pingInterval*3 {
-			node.Status = "offline"
-			node.PingMs = 0
+// expirePings counts PINGs older than the timeout as failed probes.
+func (m *Model) expirePings(node *types.SystemNode, hc config.Health, now time.Time) {
+	expired := 0
+	for expired < len(node.Pings) && now.Sub(node.Pings[expired]) >= hc.Timeout {
+		m.recordNodeSample(node.Name, node.Pings[expired], -1)
+		node.Failures++
+		if hc.PingNoun == "" {
+			m.nextPingNoun(node)
 		}
+		expired++
+	}
+	if expired == 0 {
+		return
+	}
+	node.Pings = node.Pings[expired:]
+	m.updateNodeStatus(node, hc)
+}
 
-		if node.PingSent.IsZero() || now.Sub(node.PingSent) >= pingInterval {
-			m.pingNode(node)
+// updateNodeStatus derives the status from failed probes and the last RTT:
+// offline at the failure threshold, degraded on fewer failures or a slow answer.
+func (m *Model) updateNodeStatus(node *types.SystemNode, hc config.Health) {
+	status := "online"
+	switch {
+	case node.Failures >= hc.Failures:
+		status = "offline"
+	case node.LastSeen.IsZero():
+		return // never answered: keep the initial status until the threshold
+	case node.Failures > 0:
+		status = "degraded"
+	case hc.Slow > 0 && node.PingMs > hc.Slow.Milliseconds():
+		status = "degraded"
+	}
+	m.setNodeStatus(node, status)
+}
+
+// setNodeStatus records a status change in the node history and notifies when a node goes offline.
+func (m *Model) setNodeStatus(node *types.SystemNode, status string) {
+	if node.Status == status {
+		return
+	}
+	was := node.Status
+	node.Status = status
+	node.Since = time.Now()
+	if status == "offline" {
+		node.PingMs = 0
+		if was != "online" && was != "degraded" {
+			return
+		}
+		since := "startup"
+		if !node.LastSeen.IsZero() {
+			since = node.LastSeen.Format("15:04")
 		}
+		m.notify(notify.KindOffline, "Node offline", node.Name+" has not answered since "+since)
 	}
+	m.recordNodeEvent(node.Name, status, time.Now())
 }
 
+// pingNode sends PING plus the GET probes; the send time is queued so each PONG is timed against its own PING.
 func (m *Model) pingNode(node *types.SystemNode) {
-	node.PingSent = time.Now()
-	m.HubSend(node.Name, "PING", node.PingNoun)
-	m.HubSend(node.Name, "GET", "UPTIME")
+	hc := m.Config.HealthFor(node.Name)
+	now := time.Now()
+	node.PingSent = now
+	node.Pings = append(node.Pings, now)
+	noun := node.PingNoun
+	if hc.PingNoun != "" {
+		noun = hc.PingNoun
+	}
+	m.HubSend(node.Name, "PING", noun)
+	for _, probe := range hc.Probes {
+		m.HubSend(node.Name, "GET", probe)
+	}
 }
 
 func (m *Model) pingSelectedNode() {
@@ -214,11 +287,16 @@ func (m *Model) handleNodeResponse(msg monolink.Message) {
 		switch verb {
 		case "PONG":
 			now := time.Now()
-			node.Status = "online"
 			node.LastSeen = now
-			if !node.PingSent.IsZero() {
-				node.PingMs = now.Sub(node.PingSent).Milliseconds()
+			if len(node.Pings) > 0 {
+				node.PingMs = now.Sub(node.Pings[0]).Milliseconds()
+				node.Pings = node.Pings[1:]
+				m.recordNodeSample(node.Name, now, node.PingMs)
 			}
+			node.Failures = 0
+			m.learnPingNoun(node, msg)
+			m.updateNodeStatus(node, m.Config.HealthFor(node.Name))
+			m.checkNodeAlerts(node)
 			if from == "GOVERNOR" {
 				m.requestGovernorEvents()
 				m.requestGovernorDeadlines()
@@ -228,6 +306,13 @@ func (m *Model) handleNodeResponse(msg monolink.Message) {
 			topic := strings.ToUpper(msg.Noun)
 			if topic == "UPTIME" && len(msg.Args) >= 1 {
 				node.Uptime = parseUptime(msg.Args[0])
+				m.recordNodeUptime(node.Name, msg.Args[0])
+			}
+			if len(msg.Args) >= 1 && slices.Contains(m.Config.HealthFor(node.Name).Probes, topic) {
+				if node.Probes == nil {
+					node.Probes = map[string]string{}
+				}
+				node.Probes[topic] = strings.Join(msg.Args, ":")
 			}
 		}
 		return
@@ -235,10 +320,7 @@ func (m *Model) handleNodeResponse(msg monolink.Message) {
 }
 
 func parseUptime(raw string) string {
-	if ms, err := strconv.ParseInt(raw, 10, 64); err == nil {
-		return formatDuration(time.Duration(ms) * time.Millisecond)
-	}
-	if d, err := time.ParseDuration(raw); err == nil {
+	if d, ok := parseUptimeDuration(raw); ok {
 		return formatDuration(d)
 	}
 	return raw
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"monoview/internal/config"
+	"monoview/internal/store"
+)
+
+// Timer presets: the new-timer form opens on a numbered list of configured
+// presets followed by recently used custom timers (persisted in the XDG state
+// dir). A number fills the form, [c] goes straight to a blank custom form.
+
+const recentTimersFile = "timers.json"
+
+// maxTimerChoices is how many entries the picker numbers (keys 1-9).
+const maxTimerChoices = 9
+
+func loadRecentTimers() []config.TimerPreset {
+	var recent []config.TimerPreset
+	if path, err := store.StatePath(recentTimersFile); err == nil {
+		_ = store.Load(path, &recent)
+	}
+	return recent
+}
+
+func (m *Model) saveRecentTimers() {
+	if path, err := store.StatePath(recentTimersFile); err == nil {
+		_ = store.Save(path, m.RecentTimers)
+	}
+}
+
+// timerChoices returns the numbered picker entries: presets, then recent custom timers.
+func (m *Model) timerChoices() []config.TimerPreset {
+	choices := append([]config.TimerPreset(nil), m.Config.TimerPresets...)
+	recent := m.RecentTimers
+	if n := m.Config.RecentTimers; len(recent) > n {
+		recent = recent[:n]
+	}
+	choices = append(choices, recent...)
+	if len(choices) > maxTimerChoices {
+		choices = choices[:maxTimerChoices]
+	}
+	return choices
+}
+
+// rememberTimer records a submitted timer as recent unless it is a configured preset.
+func (m *Model) rememberTimer(name, dur string) {
+	if m.Config.RecentTimers == 0 {
+		return
+	}
+	t := config.TimerPreset{Name: name, Duration: dur}
+	for _, p := range m.Config.TimerPresets {
+		if p == t {
+			return
+		}
+	}
+	recent := []config.TimerPreset{t}
+	for _, r := range m.RecentTimers {
+		if r != t {
+			recent = append(recent, r)
+		}
+	}
+	if len(recent) > m.Config.RecentTimers {
+		recent = recent[:m.Config.RecentTimers]
+	}
+	m.RecentTimers = recent
+	m.saveRecentTimers()
+}
+
+// achtungTimerOpen opens the new-timer flow on the preset picker.
+func (m *Model) achtungTimerOpen() {
+	m.AchtungTimerMenu = true
+	m.AchtungTimerPicking = len(m.timerChoices()) > 0
+	m.AchtungTimerFocusField = 0
+	m.AchtungTimerDuration = ""
+	m.AchtungTimerName = ""
+}
+
+// handleTimerPickerKeys handles the preset list: 1-9 pick, [c]/Enter custom, Esc cancel.
+func (m *Model) handleTimerPickerKeys(msg tea.KeyMsg) bool {
+	key := msg.String()
+	switch key {
+	case "esc":
+		m.achtungTimerReset()
+	case "c", "enter":
+		m.AchtungTimerPicking = false
+	default:
+		choices := m.timerChoices()
+		if len(key) == 1 && key[0] >= '1' && int(key[0]-'0') <= len(choices) {
+			c := choices[key[0]-'1']
+			m.AchtungTimerDuration = c.Duration
+			m.AchtungTimerName = c.Name
+			m.AchtungTimerPicking = false
+			m.AchtungTimerFocusField = 1 // name; Enter submits
+		}
+	}
+	return true
+}
+
+// timerChoiceLabel is "name  5m" for a preset, or just the duration for an unnamed recent timer.
+func timerChoiceLabel(c config.TimerPreset) string {
+	if strings.TrimSpace(c.Name) == "" {
+		return c.Duration
+	}
+	return c.Name + "  " + c.Duration
+}
@@ -0,0 +1,80 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"monoview/internal/ui"
+)
+
+// renderNodeInspect is the System sheet while the node view ([i]) is open:
+// reported topics and quick actions side by side, recent traffic below.
+func (m Model) renderNodeInspect() string {
+	const boxWidth = 50
+	n := m.Nodes[m.SelectedNode]
+	header := ui.Title.Render("▌NODE "+n.Name) + " " + ui.Dim.Render(n.Status) + "  " +
+		ui.Dim.Render("[g] query all  [p] ping  [i]/[Esc] back") + "\n\n"
+
+	var topicLines []string
+	paths := m.nodePaths(n.Name)
+	if len(paths) == 0 {
+		topicLines = append(topicLines, ui.Dim.Render("  Nothing reported yet — [g] to query"))
+	}
+	for _, p := range paths {
+		v, _ := m.Props.Get(p)
+		age := ui.Dim.Render(v.At.Format("15:04:05"))
+		line := fmt.Sprintf("  %s %s", ui.Label.Render(fmt.Sprintf("%-16s", ui.TruncateString(propLabel(p), 16))), ui.Value.Render(ui.TruncateString(v.Value, boxWidth-30)))
+		topicLines = append(topicLines, ui.PadLine(line, boxWidth-12)+age)
+	}
+	topicsBox := ui.NewBox(boxWidth).WithTitle(fmt.Sprintf("TOPICS  %d", len(paths))).Render(strings.Join(topicLines, "\n"))
+
+	var actionLines []string
+	devs := m.nodeDevices(n.Name)
+	if len(devs) == 0 {
+		actionLines = append(actionLines, ui.Dim.Render("  No devices in the catalog"))
+	}
+	for i, idx := range devs {
+		actionLines = append(actionLines, m.renderDeviceLine(m.HomeDevices[idx], i == m.SelectedNodeAction))
+	}
+	actionsBox := ui.NewBox(boxWidth).WithTitle("ACTIONS  [↑↓] select  [Enter] run  [←→] adjust").Render(strings.Join(actionLines, "\n"))
+
+	top := lipgloss.JoinHorizontal(lipgloss.Top, topicsBox, "  ", actionsBox)
+
+	// Latest rejected payloads, newest first.
+	if bad := m.nodeProtoErrors(n.Name); len(bad) > 0 {
+		errLines := []string{ui.Title.Render("▌PROTOCOL ERRORS") + " " + ui.Dim.Render(fmt.Sprintf("%d since start", m.ProtoErrorCount[n.Name]))}
+		for i := len(bad) - 1; i >= 0 && len(errLines) <= 3; i-- {
+			e := bad[i]
+			style := ui.Warning
+			if e.Whole {
+				style = ui.Offline
+			}
+			errLines = append(errLines, ui.TruncateString(fmt.Sprintf("  %s %s %s", ui.Label.Render(e.At.Format("15:04:05")), style.Render(e.Reason), ui.Dim.Render(e.Raw)), 2*boxWidth+2))
+		}
+		top += "\n\n" + strings.Join(errLines, "\n")
+	}
+
+	visible := m.plainHeight() - strings.Count(header, "\n") - lipgloss.Height(top) - 3
+	if visible < 3 {
+		visible = 3
+	}
+	msgs := m.NodeTraffic[n.Name]
+	var trafficLines []string
+	if len(msgs) == 0 {
+		trafficLines = append(trafficLines, ui.Dim.Render("  No messages yet"))
+	}
+	for i := len(msgs) - 1; i >= 0 && len(trafficLines) < visible; i-- {
+		e := msgs[i]
+		dir := lipgloss.NewStyle().Foreground(ui.GruvAqua).Render("▼ rx")
+		if e.Out {
+			dir = lipgloss.NewStyle().Foreground(ui.GruvOrange).Render("▲ tx")
+		}
+		trafficLines = append(trafficLines, fmt.Sprintf("  %s %s %s", ui.Label.Render(e.At.Format("15:04:05")), dir, ui.Value.Render(ui.TruncateString(e.Text, 2*boxWidth-20))))
+	}
+	traffic := ui.Title.Render("▌TRAFFIC") + "\n" + strings.Join(trafficLines, "\n")
+
+	content := header + top + "\n\n" + traffic
+	return ui.IndentLines(content, "  ")
+}
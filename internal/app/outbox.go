@@ -0,0 +1,37 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"monoview/internal/ui"
+)
+
+func (m Model) renderOutboxPanel(minHeight int) string {
+	const width = 64
+	var lines []string
+	lines = append(lines, "")
+	title := fmt.Sprintf("  Queued commands (%d)", len(m.Outbox))
+	lines = append(lines, ui.Title.Render(title)+" ")
+	lines = append(lines, ui.Label.Render("  Replayed in order when the hub reconnects"))
+	lines = append(lines, "")
+	if len(m.Outbox) == 0 {
+		lines = append(lines, ui.Dim.Render("  Nothing queued"))
+	}
+	for i, e := range m.Outbox {
+		prefix := "  "
+		if i == m.SelectedOutbox {
+			prefix = "▌ "
+		}
+		line := prefix + ui.Label.Render(e.Queued.Format("15:04:05")) + " " + ui.Value.Render(outboxEntryString(e))
+		lines = append(lines, ui.TruncateString(line, width-3))
+	}
+	lines = append(lines, "")
+	lines = append(lines, ui.Dim.Render("  [↑/↓] select  [d] cancel  [D] cancel all  [Esc] close"))
+	inner := strings.Join(lines, "\n")
+	if minHeight > 2 {
+		inner = padToLines(inner, minHeight-2)
+	}
+	box := ui.NewBox(width).WithBorderColor(ui.GruvAqua).WithTitle(" OUTBOX ")
+	return box.Render(inner)
+}
@@ -0,0 +1,89 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"monoview/internal/ui"
+)
+
+// renderPropsSheet draws the property tree, scrolled to keep the selection in view.
+func (m Model) renderPropsSheet() string {
+	const width = 96
+	rows := m.propRows()
+	header := ui.Title.Render("▌PROPERTIES") + " " + ui.Dim.Render("every value reported in an OK reply  ✎ writable") + "\n\n"
+	if len(rows) == 0 {
+		return ui.IndentLines(header+ui.Dim.Render("Nothing reported yet. Values appear as nodes answer GET requests."), "  ")
+	}
+	visible := m.plainHeight() - 3
+	if visible < 5 {
+		visible = 5
+	}
+	start := 0
+	if m.SelectedProp >= visible {
+		start = m.SelectedProp - visible + 1
+	}
+	end := min(start+visible, len(rows))
+	now := m.LastUpdate
+	var lines []string
+	for i := start; i < end; i++ {
+		r := rows[i]
+		var name string
+		switch r.Depth {
+		case 0:
+			name = ui.Title.Render(m.propFoldIcon(r) + " " + r.Path.Node)
+		case 1:
+			name = "  " + ui.Accent.Render(m.propFoldIcon(r)+" "+r.Path.Topic)
+		default:
+			name = "      " + ui.Label.Render(r.Path.Prop)
+		}
+		line := ui.PadLine(name, 32)
+		if p, ok := m.propValuePath(r); ok {
+			v, _ := m.Props.Get(p)
+			value := v.Value
+			if m.PropEditing && i == m.SelectedProp {
+				value = ui.Value.Render(m.PropEditBuffer) + ui.Dim.Render("▌")
+			} else {
+				value = ui.Value.Render(ui.TruncateString(value, 36))
+			}
+			mark := " "
+			if m.propWritable(p) {
+				mark = ui.Accent.Render("✎")
+			}
+			line += ui.PadLine(mark+" "+value, 42) + ui.Dim.Render(propAge(now, v.At))
+		}
+		if i == m.SelectedProp {
+			line = "▌ " + line
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, ui.TruncateString(line, width))
+	}
+	if len(rows) > visible {
+		lines = append(lines, ui.Dim.Render(fmt.Sprintf("  %d–%d of %d", start+1, end, len(rows))))
+	}
+	return ui.IndentLines(header+strings.Join(lines, "\n"), "  ")
+}
+
+func (m Model) propFoldIcon(r propRow) string {
+	if !m.propHasChildren(r) {
+		return "·"
+	}
+	if m.PropsCollapsed[r.key()] {
+		return "▸"
+	}
+	return "▾"
+}
+
+// propAge is how long ago a value was reported ("12s ago", "3m ago", or the time of day).
+func propAge(now, at time.Time) string {
+	d := now.Sub(at)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds ago", max(int(d.Seconds()), 0))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	}
+	return at.Format("15:04")
+}
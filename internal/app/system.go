@@ -3,6 +3,7 @@ package app
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 
@@ -11,6 +12,15 @@ import (
 )
 
 func (m Model) renderSystem() string {
+	if m.NodeInspect && m.SelectedNode < len(m.Nodes) {
+		return m.renderNodeInspect()
+	}
+	if m.SystemDiag {
+		return m.renderConnection()
+	}
+	if m.SystemAllHubs {
+		return m.renderAllHubs()
+	}
 	// Left: nodes in 2 columns (vertical layout). Right: logs.
 	mid := (len(m.Nodes) + 1) / 2
 	col1Nodes := m.Nodes[:mid]
@@ -30,9 +40,17 @@ func (m Model) renderSystem() string {
 	col2 := lipgloss.JoinVertical(lipgloss.Left, col2Panels...)
 	nodesBlock := lipgloss.JoinHorizontal(lipgloss.Top, col1, "  ", col2)
 
-	nodesHeader := ui.Dim.Render("▌NODES") + "\n\n"
+	nodesHeader := ui.Dim.Render("▌NODES") + "\n\n\n"
 	if !m.SystemFocusLogs {
-		nodesHeader = ui.Title.Render("▌NODES") + " " + ui.Dim.Render("[←↑↓→] grid nodes  [Enter] ping") + "\n\n"
+		nodesHeader = ui.Title.Render("▌NODES") + " " + ui.Dim.Render("[←↑↓→] grid nodes  [Enter] ping  [i] node  [v] history") + "\n"
+		hint := "[p] pin  [x] hide discovered"
+		if len(m.Hubs) > 1 {
+			hint += "  [a] all hubs"
+		}
+		if n := m.hiddenNodeCount(); n > 0 {
+			hint += fmt.Sprintf("  [X] show %d hidden", n)
+		}
+		nodesHeader += "       " + ui.Dim.Render(hint) + "\n"
 	}
 	nodesSection := nodesHeader + nodesBlock
 
@@ -41,11 +59,13 @@ func (m Model) renderSystem() string {
 	if visibleLogLines < 5 {
 		visibleLogLines = 5
 	}
-	logsHeader := ui.Title.Render("▌RECENT LOGS") + "\n\n"
+	// The line under the title shows the client certificate's expiry with mTLS.
+	cert := renderCertExpiry(m.Cert, m.LastUpdate)
+	logsHeader := ui.Title.Render("▌RECENT LOGS") + "\n" + cert + "\n"
 	if m.SystemFocusLogs {
-		logsHeader = ui.Title.Render("▌RECENT LOGS") + " " + ui.Dim.Render("[↑↓] scroll") + "\n\n"
+		logsHeader = ui.Title.Render("▌RECENT LOGS") + " " + ui.Dim.Render("[↑↓] scroll") + "\n" + cert + "\n"
 	} else {
-		logsHeader = ui.Dim.Render("▌RECENT LOGS") + "\n\n"
+		logsHeader = ui.Dim.Render("▌RECENT LOGS") + "\n" + cert + "\n"
 	}
 	logs := m.Logs
 	if len(logs) > 50 {
@@ -72,19 +92,7 @@ func (m Model) renderSystem() string {
 		}
 	}
 	for _, l := range visibleLogs {
-		timeStr := l.Time.Format("15:04:05")
-		level := getLogLevelStyle(l.Level)
-		source := ui.Accent.Render(fmt.Sprintf("%-8s", l.Source))
-		msg := l.Message
-		if len(msg) > logWidth {
-			msg = msg[:logWidth] + "..."
-		}
-		line := fmt.Sprintf("%s %s %s %s",
-			ui.Label.Render(timeStr),
-			level,
-			source,
-			ui.Value.Render(msg))
-		logLines = append(logLines, line)
+		logLines = append(logLines, renderLogLine(l, logWidth))
 	}
 	logsSection := logsHeader + strings.Join(logLines, "\n")
 
@@ -92,6 +100,19 @@ func (m Model) renderSystem() string {
 	return ui.IndentLines(content, "  ")
 }
 
+// renderLogLine formats one log entry; the message is cut after width characters.
+func renderLogLine(l types.LogEntry, width int) string {
+	msg := l.Message
+	if len(msg) > width {
+		msg = msg[:width] + "..."
+	}
+	return fmt.Sprintf("%s %s %s %s",
+		ui.Label.Render(l.Time.Format("15:04:05")),
+		getLogLevelStyle(l.Level),
+		ui.Accent.Render(fmt.Sprintf("%-8s", l.Source)),
+		ui.Value.Render(msg))
+}
+
 func (m Model) renderNodePanel(n types.SystemNode, active bool) string {
 	width := 24
 
@@ -99,6 +120,8 @@ func (m Model) renderNodePanel(n types.SystemNode, active bool) string {
 	switch n.Status {
 	case "online":
 		statusLine = ui.Online.Render("● ONLINE")
+	case "degraded":
+		statusLine = ui.Warning.Render("● DEGRADED")
 	case "offline":
 		statusLine = ui.Offline.Render("● OFFLINE")
 	default:
@@ -106,7 +129,7 @@ func (m Model) renderNodePanel(n types.SystemNode, active bool) string {
 	}
 
 	var pingStr string
-	if n.Status == "online" && n.PingMs > 0 {
+	if (n.Status == "online" || n.Status == "degraded") && n.PingMs > 0 {
 		pingStr = fmt.Sprintf("%dms", n.PingMs)
 	} else {
 		pingStr = "—"
@@ -115,9 +138,22 @@ func (m Model) renderNodePanel(n types.SystemNode, active bool) string {
 	var lines []string
 	lines = append(lines, ui.PadLine(" "+ui.Title.Render(n.Name), width-2))
 	lines = append(lines, ui.PadLine(" "+statusLine, width-2))
-	lines = append(lines, "")
+	var badge string
+	switch {
+	case n.Pinned:
+		badge = " " + ui.Accent.Render("◆ pinned")
+	case n.Discovered:
+		badge = " " + ui.Warning.Render("◌ unconfigured")
+	}
+	if bad := m.ProtoErrorCount[n.Name]; bad > 0 {
+		badge += " " + ui.Offline.Render(fmt.Sprintf("⚠ %d bad", bad))
+	}
+	lines = append(lines, ui.PadLine(badge, width-2))
 	lines = append(lines, ui.PadLine(fmt.Sprintf(" %s %s", ui.Label.Render("PING:"), ui.Value.Render(pingStr)), width-2))
 	lines = append(lines, ui.PadLine(fmt.Sprintf(" %s %s", ui.Label.Render("UP:  "), ui.Value.Render(n.Uptime)), width-2))
+	h := m.NodeHistory[n.Name]
+	lines = append(lines, " "+ui.Sparkline(nodeRTTs(h, width-4), width-4)+" ")
+	lines = append(lines, ui.PadLine(fmt.Sprintf(" %s %s", ui.Label.Render("24h: "), renderAvailability(h, m.LastUpdate, 24*time.Hour)), width-2))
 
 	content := strings.Join(lines, "\n")
 
@@ -141,3 +177,111 @@ func getLogLevelStyle(level string) string {
 		return lipgloss.NewStyle().Foreground(ui.GruvGray).Width(5).Render(level)
 	}
 }
+
+// renderAvailability shows availability over window: green from 99%, yellow from 90%, else red.
+func renderAvailability(h *types.NodeHistory, now time.Time, window time.Duration) string {
+	pct, ok := nodeAvailability(h, now, window)
+	if !ok {
+		return ui.Dim.Render("—")
+	}
+	text := fmt.Sprintf("%.1f%%", pct)
+	switch {
+	case pct >= 99:
+		return ui.Online.Render(text)
+	case pct >= 90:
+		return ui.Warning.Render(text)
+	}
+	return ui.Offline.Render(text)
+}
+
+// shortDuration is d.String() without zero trailing units ("2m" rather than "2m0s").
+func shortDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// maxNodeDetailEvents is how many recent transitions the node detail view lists.
+const maxNodeDetailEvents = 8
+
+// renderNodeDetailView shows a node's health history: availability over
+// 1h/24h/7d, RTT statistics and sparkline, the health check and probe replies,
+// and recent status changes and reboots.
+func (m Model) renderNodeDetailView(minHeight int) string {
+	const width = 64
+	var lines []string
+	lines = append(lines, "")
+	if m.SelectedNode >= len(m.Nodes) {
+		lines = append(lines, ui.Label.Render("  No node selected"))
+	} else {
+		n := m.Nodes[m.SelectedNode]
+		h := m.NodeHistory[n.Name]
+		now := m.LastUpdate
+		lines = append(lines, ui.Title.Render("  "+n.Name)+" "+ui.Dim.Render(n.Status))
+		lines = append(lines, "")
+		lines = append(lines, ui.Label.Render("  Availability"))
+		for _, w := range []struct {
+			label  string
+			window time.Duration
+		}{{"1h", time.Hour}, {"24h", 24 * time.Hour}, {"7d", 7 * 24 * time.Hour}} {
+			lines = append(lines, fmt.Sprintf("    %s %s", ui.Label.Render(fmt.Sprintf("%-4s", w.label)), renderAvailability(h, now, w.window)))
+		}
+		lines = append(lines, "")
+		rtt := "—"
+		if lo, avg, hi, ok := nodeRTTStats(h, now, 24*time.Hour); ok {
+			rtt = fmt.Sprintf("min %dms  avg %dms  max %dms", lo, avg, hi)
+		}
+		lines = append(lines, ui.Label.Render("  RTT 24h: ")+ui.Value.Render(rtt))
+		lines = append(lines, "  "+ui.Sparkline(nodeRTTs(h, width-8), width-8))
+		lines = append(lines, ui.Label.Render("  Uptime: ")+ui.Value.Render(n.Uptime))
+		hc := m.Config.HealthFor(n.Name)
+		check := fmt.Sprintf("every %s, timeout %s, offline after %d", shortDuration(hc.Interval), shortDuration(hc.Timeout), hc.Failures)
+		if hc.Slow > 0 {
+			check += fmt.Sprintf(", slow > %s", shortDuration(hc.Slow))
+		}
+		lines = append(lines, ui.Label.Render("  Check: ")+ui.Value.Render(check))
+		if n.Failures > 0 {
+			lines = append(lines, ui.Label.Render("  Failed: ")+ui.Warning.Render(fmt.Sprintf("%d in a row", n.Failures)))
+		}
+		for _, p := range hc.Probes {
+			if p == "UPTIME" {
+				continue
+			}
+			v, ok := n.Probes[p]
+			if !ok {
+				v = "—"
+			}
+			lines = append(lines, ui.Label.Render(fmt.Sprintf("  %s: ", p))+ui.Value.Render(ui.TruncateString(v, width-8-len(p))))
+		}
+		lines = append(lines, "")
+		lines = append(lines, ui.Label.Render("  Recent events"))
+		var events []types.NodeEvent
+		if h != nil {
+			events = h.Events
+		}
+		if len(events) == 0 {
+			lines = append(lines, ui.Dim.Render("    none recorded"))
+		}
+		for i := len(events) - 1; i >= 0 && i >= len(events)-maxNodeDetailEvents; i-- {
+			e := events[i]
+			style := ui.Online
+			switch e.Kind {
+			case "offline":
+				style = ui.Offline
+			case "reboot", "degraded":
+				style = ui.Warning
+			}
+			lines = append(lines, fmt.Sprintf("    %s  %s", ui.Label.Render(e.At.Format("Mon 02 Jan 15:04")), style.Render(e.Kind)))
+		}
+	}
+	lines = append(lines, "")
+	lines = append(lines, ui.Dim.Render("  [←↑↓→] node  [v] / [Esc] close"))
+	inner := padToLines(strings.Join(lines, "\n"), minHeight-2)
+	box := ui.NewBox(width).WithBorderColor(ui.GruvAqua).WithTitle(" NODE HISTORY ")
+	return box.Render(inner)
+}
@@ -15,6 +15,13 @@ func (m Model) View() string {
 	if m.Width == 0 {
 		return "Loading..."
 	}
+	if len(m.FireAlerts) > 0 {
+		// Pending fire alerts take over the frame until acknowledged.
+		return lipgloss.Place(m.Width, m.Height, lipgloss.Center, lipgloss.Center, m.renderFireAlertPopup())
+	}
+	if m.AchtungBigView {
+		return m.renderAchtungBigView()
+	}
 
 	var b strings.Builder
 
@@ -37,6 +44,12 @@ func (m Model) View() string {
 		b.WriteString(m.renderHome(showAchtungFormInline))
 	case types.SheetSystem:
 		b.WriteString(m.renderSystem())
+	case types.SheetDeadlines:
+		b.WriteString(m.renderDeadlineSheet())
+	case types.SheetProps:
+		b.WriteString(m.renderPropsSheet())
+	case types.SheetDashboard:
+		b.WriteString(m.renderDashboard())
 	}
 
 	content := b.String()
@@ -50,41 +63,75 @@ func (m Model) View() string {
 	}
 
 	fullView := content + strings.Repeat("\n", padding) + footer
+	if m.kioskNight() {
+		fullView = ui.Dimmed(fullView)
+	}
 
-	// Right panel: Calendar (add-event, event details) or Home (timer/alarm forms, job details).
+	// Right panel: outbox review or alerts (any sheet), Calendar (add-event, event details) or Home (timer/alarm forms, job details).
+	if m.OutboxView || m.AlertsView {
+		return m.renderWithRightPanel(fullView)
+	}
 	if m.ActiveSheet == types.SheetCalendar && (m.EventAddMenu || m.EventViewMenu) {
 		return m.renderWithRightPanel(fullView)
 	}
 	if m.ActiveSheet == types.SheetHome && (m.AchtungTimerMenu || m.AchtungAlarmMenu || m.AchtungViewMenu) {
 		return m.renderWithRightPanel(fullView)
 	}
-
-	// Fire alert still uses a centered popup (takes over the frame).
-	if m.FireAlert.Show {
-		return lipgloss.Place(m.Width, m.Height, lipgloss.Center, lipgloss.Center, m.renderFireAlertPopup())
+	if m.ActiveSheet == types.SheetSystem && m.NodeDetailView {
+		return m.renderWithRightPanel(fullView)
 	}
 
 	return fullView
 }
 
+// renderFireAlertPopup draws the selected alert with the rest of the pending stack below it.
 func (m Model) renderFireAlertPopup() string {
-	const width = 44
-	kind := m.FireAlert.JobKind
-	name := m.FireAlert.JobName
-	title := kind + " fired!"
-	body := ui.Accent.Render(name)
-	action := "[ Enter ] Turn off buzzer"
-	inner := strings.Join([]string{
+	const width = 48
+	sel := m.SelectedFireAlert
+	if sel < 0 || sel >= len(m.FireAlerts) {
+		sel = 0
+	}
+	a := m.FireAlerts[sel]
+	body := ui.Accent.Render(a.JobName)
+	if m.Pomodoro.Active && strings.HasPrefix(a.JobName, "pomo_") {
+		body += ui.Dim.Render("  next: " + pomodoroPhaseLabel(m.Pomodoro.Phase) + " " + formatSnooze(m.pomodoroPhaseDuration(m.Pomodoro.Phase)))
+	}
+	lines := []string{
 		"",
-		ui.Title.Render("  "+title) + " ",
+		ui.Title.Render("  "+a.JobKind+" fired!") + " " + ui.Dim.Render(a.FiredAt.Format("15:04:05")),
 		"",
 		"  " + body,
 		"",
-		ui.Label.Render("  "+action) + " ",
-		"",
-	}, "\n")
-	box := ui.NewBox(width).WithBorderColor(ui.GruvYellow).WithTitle(" ALARM ")
-	return box.Render(inner)
+	}
+	if len(m.FireAlerts) > 1 {
+		for i, p := range m.FireAlerts {
+			line := fmt.Sprintf("%-6s %-24s %s", p.JobKind, ui.TruncateString(p.JobName, 24), p.FiredAt.Format("15:04:05"))
+			if i == sel {
+				lines = append(lines, ui.Value.Render("▌ "+line))
+			} else {
+				lines = append(lines, ui.Dim.Render("  "+line))
+			}
+		}
+		lines = append(lines, "")
+	}
+	action := "[ Enter ] Dismiss"
+	if len(m.FireAlerts) == 1 {
+		action = "[ Enter ] Turn off buzzer"
+	}
+	lines = append(lines,
+		ui.Label.Render("  "+action)+" ",
+		ui.Label.Render("  [ s ] Snooze "+formatSnooze(m.snoozeFor()))+" ",
+	)
+	if len(m.FireAlerts) > 1 {
+		lines = append(lines, ui.Label.Render("  [ j/k ] select  [ A ] dismiss all")+" ")
+	}
+	lines = append(lines, "")
+	title := " ALARM "
+	if len(m.FireAlerts) > 1 {
+		title = fmt.Sprintf(" ALARM %d/%d ", sel+1, len(m.FireAlerts))
+	}
+	box := ui.NewBox(width).WithBorderColor(ui.GruvYellow).WithTitle(title)
+	return box.Render(strings.Join(lines, "\n"))
 }
 
 // renderCalendarWithAddForm is used when building content; the right-edge layout is done in renderWithRightPanel.
@@ -120,7 +167,11 @@ func (m Model) renderWithRightPanel(fullView string) string {
 		leftWidth = 8
 	}
 	var rightContent string
-	if m.EventAddMenu {
+	if m.OutboxView {
+		rightContent = m.renderOutboxPanel(contentHeight)
+	} else if m.AlertsView {
+		rightContent = m.renderAlertsPanel(contentHeight)
+	} else if m.EventAddMenu {
 		rightContent = m.renderEventAddFormInner(contentHeight)
 	} else if m.EventViewMenu {
 		dayEvents := m.eventsForSelectedDate()
@@ -129,6 +180,8 @@ func (m Model) renderWithRightPanel(fullView string) string {
 		} else {
 			rightContent = m.renderEventDetailView(types.Event{}, contentHeight)
 		}
+	} else if m.ActiveSheet == types.SheetSystem && m.NodeDetailView {
+		rightContent = m.renderNodeDetailView(contentHeight)
 	} else if m.ActiveSheet == types.SheetHome {
 		if m.AchtungTimerMenu || m.AchtungAlarmMenu {
 			rightContent = m.renderAchtungFormBox(contentHeight)
@@ -178,6 +231,11 @@ func (m Model) renderHeader() string {
  |  |  | |_____| |  \_| |_____| |_____ __|__    |    |     |`
 
 	logoStyled := lipgloss.NewStyle().Foreground(ui.GruvOrange).Render(logo)
+	if m.Kiosk.Enabled {
+		// Readable across the hallway.
+		logo = ui.BigText(m.LastUpdate.Format("15:04"))
+		logoStyled = ui.Accent.Render(logo)
+	}
 
 	clock := m.LastUpdate.Format("15:04:05")
 	date := m.LastUpdate.Format("Mon, 02 Jan 2006")
@@ -205,7 +263,7 @@ func (m Model) renderHeader() string {
 }
 
 func (m Model) renderHubStatus() string {
-	const width = 12
+	width := 14
 	now := time.Now()
 	trafficWindow := 500 * time.Millisecond
 
@@ -232,9 +290,23 @@ func (m Model) renderHubStatus() string {
 		txArrow = lipgloss.NewStyle().Foreground(ui.GruvOrange).Bold(true).Render("▲")
 	}
 
+	queued := ""
+	if len(m.Outbox) > 0 {
+		queued = " " + ui.Warning.Render(fmt.Sprintf("Q%d", len(m.Outbox)))
+	}
+
+	// With several hubs the box names the active one and where it is in the [@] cycle.
+	name := "HUB"
+	position := ""
+	if len(m.Hubs) > 1 {
+		name = strings.ToUpper(m.Name)
+		position = " " + ui.Dim.Render(fmt.Sprintf("%d/%d", m.index+1, len(m.Hubs)))
+		width = max(width, lipgloss.Width(name+queued)+8, lipgloss.Width(statusLabel+position)+6)
+	}
+
 	var lines []string
-	lines = append(lines, ui.PadLine(" "+dot+" "+statusLabel, width-2))
-	lines = append(lines, ui.PadLine(" "+txArrow+" "+rxArrow+" "+ui.Label.Render("HUB"), width-2))
+	lines = append(lines, ui.PadLine(" "+dot+" "+statusLabel+position, width-2))
+	lines = append(lines, ui.PadLine(" "+txArrow+" "+rxArrow+" "+ui.Label.Render(name)+queued, width-2))
 
 	content := strings.Join(lines, "\n")
 	return ui.NewBox(width).Render(content)
@@ -252,47 +324,100 @@ func (m Model) renderTabs() string {
 	}
 
 	tabBar := lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
+	if banner := m.renderAlertBanner(m.Width - lipgloss.Width(tabBar) - 6); banner != "" {
+		gap := m.Width - lipgloss.Width(tabBar) - lipgloss.Width(banner) - 4
+		tabBar += strings.Repeat(" ", max(gap, 2)) + banner
+	}
 	line := ui.Dim.Render(strings.Repeat("─", m.Width))
 
 	return fmt.Sprintf("  %s\n%s", tabBar, line)
 }
 
 func (m Model) renderFooter() string {
+	if m.QuickAddInput {
+		return m.renderQuickAddPrompt()
+	}
+	if m.OutboxView {
+		return ui.Help.Render("  [↑/k ↓/j] select  [d] cancel  [D] cancel all  [Esc/o] close  [q] quit")
+	}
+	if m.AlertsView {
+		return ui.Help.Render("  [↑/k ↓/j] select  [Enter/a] acknowledge  [A] acknowledge all  [Esc/!] close  [q] quit")
+	}
+	if m.Kiosk.Enabled {
+		help := "kiosk  [1-7] sheets  [Ctrl+C] quit"
+		if m.Kiosk.Rotate > 0 {
+			help += "  ·  next sheet every " + shortDuration(m.Kiosk.Rotate)
+		}
+		return ui.Help.Render("  " + help)
+	}
 	var help string
 	switch m.ActiveSheet {
 	case types.SheetCalendar:
 		if m.EventAddMenu {
-			help = "[Tab] next field  [Shift+Tab] prev  [Enter] submit  [Esc] cancel  [a/n] add event"
+			help = "[Tab] next field  [Shift+Tab] prev  [←→↑↓] pick date/time  [Enter] submit  [Esc] cancel"
 		} else if m.EventViewMenu {
-			help = "[d] delete event  [Esc] close  [a/n] add  [1-4] sheets  [q] quit"
+			help = "[d] delete event  [Esc] close  [a/n] add  [1-7] sheets  [q] quit"
 		} else if m.CalendarFocusEvents {
-			help = "[↑/↓] select event  [Enter] view  [d] delete  [Esc] back  [a/n] add  [1-4] sheets  [q] quit"
+			help = "[↑/↓] select event  [Enter] view  [d] delete  [Esc] back  [a/n] add  [1-7] sheets  [q] quit"
 		} else {
-			help = "[↑/↓] week  [←/→] day  [Enter] select day → events  [a/n] add  [1-4] sheets  [q] quit"
+			help = "[↑/↓] week  [←/→] day  [Enter] select day → events  [a/n] add  [1-7] sheets  [q] quit"
 		}
 	case types.SheetDiary:
-		help = "[↑/k] prev  [↓/j] next  [1-4] sheets  [q] quit"
+		help = "[↑/k] prev  [↓/j] next  [1-7] sheets  [q] quit"
 	case types.SheetHome:
 		if m.AchtungTimerMenu || m.AchtungAlarmMenu {
-			help = "[Tab] next field  [Enter] submit  [Esc] cancel  [t] timer  [a] alarm  [q] quit"
+			help = "[Tab] next field  [←→↑↓] pick date/time/preset  [Enter] submit  [Esc] cancel"
 		} else if m.AchtungViewMenu {
-			help = "[d] stop  [Esc] close  [1-4] sheets  [q] quit"
+			help = "[p] pause/resume  [e] +5m  [d] stop  [Esc] close  [1-7] sheets  [q] quit"
 		} else if m.HomeFocusAchtung {
-			help = "[tab] VERTEX/UKAZ  [↑/k ↓/j] job  [Enter] details  [t] timer  [a] alarm  [d] stop  [1-4] sheets  [q] quit"
+			help = "[tab] VERTEX/UKAZ  [↑/k ↓/j] job  [Enter] details  [t] timer  [a] alarm  [p] pause  [e] +5m  [d] stop  [q] quit"
 		} else if m.HomeFocusUkaz {
-			help = "[tab] VERTEX/ACHTUNG  [↑/k ↓/j] UKAZ  [enter] trigger  [1-4] sheets  [q] quit"
+			help = "[tab] VERTEX/ACHTUNG  [↑/k ↓/j] UKAZ  [enter] trigger  [1-7] sheets  [q] quit"
 		} else {
-			help = "[tab] UKAZ/ACHTUNG  [↑/k ↓/j] device  [enter] toggle  [←/h →/l] adjust  [1-4] sheets  [q] quit"
+			help = "[tab] UKAZ/ACHTUNG  [↑/k ↓/j] device  [enter] toggle  [←/h →/l] adjust  [1-7] sheets  [q] quit"
 		}
 	case types.SheetSystem:
 		if m.SystemCommandInput {
 			help = ": " + m.SystemCommandBuffer + "▌  [Enter] send  [Esc] cancel"
+		} else if m.NodeInspect {
+			help = "[↑/↓] action  [Enter] run  [←/→] adjust  [g] query all  [p] ping  [v] history  [Esc] back  [q] quit"
+		} else if m.SystemDiag {
+			help = "[r] retry now  [c/Esc] back  [1-7] sheets  [q] quit"
+		} else if m.SystemAllHubs {
+			help = "[↑/k ↓/j] select  [Enter] show on its hub  [a/Esc] back  [1-7] sheets  [q] quit"
 		} else if m.SystemFocusLogs {
-			help = "[Tab] nodes  [:] command  [1-4] sheets  [q] quit"
+			help = "[Tab] nodes  [:] command  [c] connection  [1-7] sheets  [q] quit"
 		} else {
-			help = "[Tab] logs  [:] command  [1-4] sheets  [q] quit"
+			help = "[Tab] logs  [:] command  [c] connection  [1-7] sheets  [q] quit"
 		}
+	case types.SheetDeadlines:
+		help = "[↑/k ↓/j] select  [Enter/x] done  [s] sort  [f] filter  [1-7] sheets  [q] quit"
+	case types.SheetProps:
+		if m.PropEditing {
+			help = "type the new value  [Enter] SET  [Esc] cancel"
+		} else {
+			help = "[↑/k ↓/j] select  [←/h →/l] fold  [Enter] fold / edit  [r] refresh  [1-7] sheets  [q] quit"
+		}
+	case types.SheetDashboard:
+		help = "[↑/k ↓/j] device  [Enter] toggle  [←/h →/l] adjust  [1-7] sheets  [q] quit"
 	}
 
+	if len(m.Hubs) > 1 {
+		help = strings.Replace(help, "[1-7] sheets", "[@] hub  [1-7] sheets", 1)
+	}
 	return ui.Help.Render("  " + help)
 }
+
+// renderQuickAddPrompt shows the quick-add input with a live preview of the parsed command.
+func (m Model) renderQuickAddPrompt() string {
+	prompt := ui.Accent.Render("  + ") + ui.Value.Render(m.QuickAddBuffer) + ui.Dim.Render("▌")
+	var preview string
+	if strings.TrimSpace(m.QuickAddBuffer) == "" {
+		preview = ui.Help.Render("e.g. dentist tomorrow 14:30 @clinic · timer 25m pomodoro · alarm mon 07:15 gym")
+	} else if q, err := parseQuickAdd(m.QuickAddBuffer, time.Now()); err != nil {
+		preview = ui.Offline.Render("✗ " + err.Error())
+	} else {
+		preview = ui.Online.Render("→ "+q.preview()) + ui.Help.Render("  [Enter] send")
+	}
+	return prompt + "   " + preview + ui.Help.Render("  [Esc] cancel")
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"fmt"
 	"strings"
 
 	"monoview/internal/types"
@@ -15,25 +16,14 @@ func (m Model) renderEventAddForm() string {
 
 func (m Model) renderEventAddFormInner(minHeight int) string {
 	const width = 64
-	labels := []string{"Title", "Date (YYYY-MM-DD)", "Time (HH:MM)", "Location", "Notes", "Visible from (opt)"}
-	values := []string{m.EventAddTitle, m.EventAddDate, m.EventAddTime, m.EventAddLocation, m.EventAddNotes, m.EventAddVisibleFrom}
-	focus := m.EventAddFocusField
-	if focus < 0 || focus > 5 {
-		focus = 0
-	}
+	fields := m.eventAddFields()
 	var lines []string
 	lines = append(lines, "")
 	lines = append(lines, ui.Title.Render("  New event")+" ")
 	lines = append(lines, "")
-	for i := 0; i < 6; i++ {
-		line := ui.Label.Render("  "+labels[i]+": ") + ui.Value.Render(values[i])
-		if i == focus {
-			line += ui.Dim.Render("▌")
-		}
-		lines = append(lines, line)
-	}
+	lines = append(lines, renderFormFields(fields, m.EventAddFocusField)...)
 	lines = append(lines, "")
-	lines = append(lines, ui.Label.Render("  [Esc] cancel  [Tab] next  [Enter] submit")+" ")
+	lines = append(lines, renderFormActions(fields)+" ")
 	lines = append(lines, "")
 	inner := strings.Join(lines, "\n")
 	if minHeight > 2 {
@@ -92,43 +82,32 @@ func (m Model) renderAchtungFormBox(minHeight int) string {
 	const width = 64
 	var lines []string
 	lines = append(lines, "")
-	if m.AchtungTimerMenu {
+	if m.AchtungTimerMenu && m.AchtungTimerPicking {
 		lines = append(lines, ui.Title.Render("  New timer")+" ")
 		lines = append(lines, "")
-		for i, label := range []string{"Duration (e.g. 5m, 1h)", "Name (optional)"} {
-			val := m.AchtungTimerDuration
-			if i == 1 {
-				val = m.AchtungTimerName
-			}
-			line := ui.Label.Render("  "+label+": ") + ui.Value.Render(val)
-			if i == m.AchtungTimerFocusField {
-				line += ui.Dim.Render("▌")
+		presets := len(m.Config.TimerPresets)
+		for i, c := range m.timerChoices() {
+			if i == presets {
+				lines = append(lines, "", ui.Dim.Render("  Recent"))
 			}
-			lines = append(lines, line)
+			lines = append(lines, fmt.Sprintf("  %s %s", ui.Accent.Render(fmt.Sprintf("[%d]", i+1)), ui.Value.Render(timerChoiceLabel(c))))
 		}
 		lines = append(lines, "")
-		lines = append(lines, ui.Dim.Render("  [Tab] next  [Enter] submit  [Esc] cancel"))
+		lines = append(lines, ui.Dim.Render("  [1-9] pick  [c] custom  [Esc] cancel"))
+	} else if m.AchtungTimerMenu {
+		fields := m.achtungTimerFields()
+		lines = append(lines, ui.Title.Render("  New timer")+" ")
+		lines = append(lines, "")
+		lines = append(lines, renderFormFields(fields, m.AchtungTimerFocusField)...)
+		lines = append(lines, "")
+		lines = append(lines, renderFormActions(fields))
 	} else if m.AchtungAlarmMenu {
+		fields := m.achtungAlarmFields()
 		lines = append(lines, ui.Title.Render("  New alarm")+" ")
 		lines = append(lines, "")
-		for i, label := range []string{"Date (YYYY-MM-DD)", "Time (HH:MM)", "Name (optional)"} {
-			var val string
-			switch i {
-			case 0:
-				val = m.AchtungAlarmDate
-			case 1:
-				val = m.AchtungAlarmTime
-			case 2:
-				val = m.AchtungAlarmName
-			}
-			line := ui.Label.Render("  "+label+": ") + ui.Value.Render(val)
-			if i == m.AchtungAlarmFocusField {
-				line += ui.Dim.Render("▌")
-			}
-			lines = append(lines, line)
-		}
+		lines = append(lines, renderFormFields(fields, m.AchtungAlarmFocusField)...)
 		lines = append(lines, "")
-		lines = append(lines, ui.Dim.Render("  [Tab] next  [Enter] submit  [Esc] cancel"))
+		lines = append(lines, renderFormActions(fields))
 	}
 	inner := strings.Join(lines, "\n")
 	if minHeight > 2 {
@@ -167,8 +146,19 @@ func (m Model) renderAchtungJobDetailView(j types.AchtungJob, minHeight int) str
 		if j.Due != "" && j.Due != "—" {
 			lines = append(lines, ui.Label.Render("  Due: ")+ui.Value.Render(j.Due))
 		}
+		if j.Paused {
+			lines = append(lines, ui.Label.Render("  State: ")+ui.Warning.Render("paused"))
+		}
+		if r := m.recurringAlarm(j.Name); r != nil {
+			repeat := repeatLabel(r.Days) + " at " + r.Time
+			if r.SkipHolidays {
+				repeat += ", skips holidays"
+			}
+			lines = append(lines, ui.Label.Render("  Repeat: ")+ui.Accent.Render(repeat))
+			lines = append(lines, ui.Label.Render("  Next: ")+ui.Value.Render(r.NextAt.Format("Mon 02 Jan 15:04")))
+		}
 		lines = append(lines, "")
-		lines = append(lines, ui.Dim.Render("  [d] stop/delete  [Esc] close"))
+		lines = append(lines, ui.Dim.Render("  [p] pause/resume  [e] +5m  [d] stop/delete  [Esc] close"))
 	}
 	inner := strings.Join(lines, "\n")
 	if minHeight > 2 {
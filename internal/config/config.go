@@ -0,0 +1,587 @@
+// Package config loads monoview's optional JSON config file
+// ($XDG_CONFIG_HOME/monoview/config.json). Every setting has a default, so a
+// missing file or a partial one is fine.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"monoview/internal/notify"
+	"monoview/internal/props"
+	"monoview/internal/store"
+)
+
+// Config is the whole config file.
+type Config struct {
+	// TimerPresets are offered by number in the new-timer form.
+	TimerPresets []TimerPreset `json:"timer_presets"`
+	// RecentTimers is how many recently used custom timers are listed after the presets.
+	RecentTimers int `json:"recent_timers"`
+	// Notify maps an event kind (timer, alarm, offline, deadline) to notifier
+	// names (bell, osc9, osc777, notify-send, hook). Kinds not set keep their default.
+	Notify map[string][]string `json:"notify"`
+	// NotifyHook is the shell command run by the "hook" notifier.
+	NotifyHook string `json:"notify_hook"`
+	// Health is the node health check for every node; Nodes overrides it per
+	// node name (fields left out keep the Health value).
+	Health HealthCheck            `json:"health"`
+	Nodes  map[string]HealthCheck `json:"nodes"`
+	// Alerts are rules checked on node state changes; raised alerts show in
+	// the header until acknowledged.
+	Alerts []AlertRule `json:"alerts"`
+	// Writable lists property paths (NODE.TOPIC.PROP, "*" matches any part)
+	// that can be changed with SET in the property browser, on top of the
+	// properties of the Home devices.
+	Writable []string `json:"writable"`
+	// Dashboard lays out the widgets of the Dashboard sheet.
+	Dashboard Dashboard `json:"dashboard"`
+	// Hubs are the concentrators to connect to (the connection profiles of
+	// `monoview profile`). Empty means the single hub given by --url and the
+	// TLS flags.
+	Hubs []HubProfile `json:"hubs"`
+	// Hub names the hub active at startup; empty means the first.
+	Hub string `json:"hub"`
+}
+
+// HubProfile is one concentrator connection.
+type HubProfile struct {
+	Name       string `json:"name"`
+	URL        string `json:"url"`
+	TLSCert    string `json:"tls_cert,omitempty"`
+	TLSKey     string `json:"tls_key,omitempty"`
+	TLSCA      string `json:"tls_ca,omitempty"`
+	ServerName string `json:"tls_server_name,omitempty"`
+}
+
+// FindHub returns the index of the hub named name (case-insensitive), or -1.
+func (c Config) FindHub(name string) int {
+	for i, h := range c.Hubs {
+		if strings.EqualFold(h.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Dashboard is a grid of widgets filled row by row.
+type Dashboard struct {
+	Columns int      `json:"columns"`
+	Widgets []Widget `json:"widgets"`
+}
+
+// Widget types.
+var WidgetTypes = []string{"clock", "schedule", "deadlines", "achtung", "devices", "nodes", "sparkline", "logs", "property"}
+
+// Widget is one dashboard cell.
+type Widget struct {
+	// Type is one of WidgetTypes.
+	Type string `json:"type"`
+	// Title replaces the widget's default title.
+	Title string `json:"title"`
+	// Node selects the node for devices, sparkline and nodes (default: all nodes).
+	Node string `json:"node"`
+	// Property is the NODE.TOPIC[.PROP] path shown by a property widget.
+	Property string `json:"property"`
+	// Rows limits list widgets (deadlines, logs).
+	Rows int `json:"rows"`
+	// Span is how many columns the widget takes (default 1, at most columns).
+	Span int `json:"span"`
+}
+
+// Alert conditions.
+const (
+	AlertOffline = "offline" // node offline for at least For
+	AlertRTT     = "rtt"     // round-trip above Above
+	AlertReboot  = "reboot"  // uptime went backwards
+	AlertValue   = "value"   // a property's value matches Equals, or is above Above / below Below
+)
+
+// AlertRule raises an alert when a node meets a condition.
+type AlertRule struct {
+	// Name labels the alert; defaults to the condition.
+	Name string `json:"name"`
+	// Node is the node name the rule applies to; "" or "*" for every node.
+	Node string `json:"node"`
+	// When is the condition: offline, rtt, reboot or value.
+	When string `json:"when"`
+	// For is how long an offline node must stay offline ("" = at once).
+	For string `json:"for"`
+	// Above is the rtt threshold (a duration), or for value rules a number.
+	Above string `json:"above"`
+	// Property is the NODE.TOPIC[.PROP] path a value rule watches.
+	Property string `json:"property"`
+	// Equals and Below are the other value conditions.
+	Equals string `json:"equals"`
+	Below  string `json:"below"`
+	// Broadcast sends ALL:ALERT:<node>:<name> to the hub when raised.
+	Broadcast bool `json:"broadcast"`
+	// Hook is a shell command run when raised, with the alert in
+	// MONOVIEW_EVENT (alert), MONOVIEW_TITLE and MONOVIEW_BODY.
+	Hook string `json:"hook"`
+}
+
+// AppliesTo reports whether the rule covers node.
+func (r AlertRule) AppliesTo(node string) bool {
+	return r.Node == "" || r.Node == "*" || strings.EqualFold(r.Node, node)
+}
+
+// Label is the rule name, or its condition when unnamed.
+func (r AlertRule) Label() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	switch r.When {
+	case AlertOffline:
+		if r.For != "" {
+			return "offline " + r.For
+		}
+	case AlertRTT:
+		return "rtt > " + r.Above
+	case AlertValue:
+		switch {
+		case r.Equals != "":
+			return r.Property + " = " + r.Equals
+		case r.Above != "":
+			return r.Property + " > " + r.Above
+		case r.Below != "":
+			return r.Property + " < " + r.Below
+		}
+	}
+	return r.When
+}
+
+// Durations returns For and Above parsed (zero when unset; Above is not a duration for value rules).
+func (r AlertRule) Durations() (forDur, above time.Duration) {
+	// validate has checked both.
+	forDur, _ = time.ParseDuration(r.For)
+	above, _ = time.ParseDuration(r.Above)
+	return forDur, above
+}
+
+// HealthCheck is how a node is probed. Durations are Go durations ("90s", "2m").
+type HealthCheck struct {
+	// Interval is the time between probes.
+	Interval string `json:"interval"`
+	// Timeout is how long a PING may go unanswered before it counts as failed.
+	Timeout string `json:"timeout"`
+	// Failures is how many probes in a row must fail before the node is
+	// offline; fewer make it degraded.
+	Failures int `json:"failures"`
+	// Slow is the round-trip above which an answering node is degraded ("" = never).
+	Slow string `json:"slow"`
+	// PingNoun overrides the noun sent with PING (e.g. "PINT" for VERTEX).
+	PingNoun string `json:"ping_noun"`
+	// Probes are nouns requested with GET alongside each PING.
+	Probes []string `json:"probes"`
+}
+
+// Health is a HealthCheck with its durations parsed.
+type Health struct {
+	Interval time.Duration
+	Timeout  time.Duration
+	Failures int
+	Slow     time.Duration
+	PingNoun string
+	Probes   []string
+}
+
+// TimerPreset is a named timer duration such as {"name": "tea", "duration": "3m"}.
+type TimerPreset struct {
+	Name     string `json:"name"`
+	Duration string `json:"duration"`
+}
+
+// Default returns the settings used when the file does not set them.
+func Default() Config {
+	return Config{
+		TimerPresets: []TimerPreset{
+			{Name: "tea", Duration: "3m"},
+			{Name: "break", Duration: "5m"},
+			{Name: "pasta", Duration: "10m"},
+			{Name: "nap", Duration: "20m"},
+			{Name: "focus", Duration: "25m"},
+			{Name: "laundry", Duration: "1h"},
+		},
+		RecentTimers: 3,
+		Notify: map[string][]string{
+			notify.KindTimer: {"bell"},
+			notify.KindAlarm: {"bell"},
+		},
+		Health: HealthCheck{
+			Interval: "2m",
+			Timeout:  "10s",
+			Failures: 3,
+			Probes:   []string{"UPTIME"},
+		},
+		Alerts: []AlertRule{
+			{When: AlertOffline, For: "5m"},
+			{When: AlertReboot},
+		},
+		Dashboard: Dashboard{
+			Columns: 3,
+			Widgets: []Widget{
+				{Type: "clock"},
+				{Type: "schedule"},
+				{Type: "deadlines", Rows: 5},
+				{Type: "achtung"},
+				{Type: "devices", Node: "VERTEX"},
+				{Type: "nodes"},
+				{Type: "logs", Rows: 6, Span: 3},
+			},
+		},
+	}
+}
+
+// HealthFor returns the health check for node: Health with the node's overrides applied.
+func (c Config) HealthFor(node string) Health {
+	hc := c.Health
+	if o, ok := c.Nodes[strings.ToUpper(node)]; ok {
+		if o.Interval != "" {
+			hc.Interval = o.Interval
+		}
+		if o.Timeout != "" {
+			hc.Timeout = o.Timeout
+		}
+		if o.Failures != 0 {
+			hc.Failures = o.Failures
+		}
+		if o.Slow != "" {
+			hc.Slow = o.Slow
+		}
+		if o.PingNoun != "" {
+			hc.PingNoun = o.PingNoun
+		}
+		if o.Probes != nil {
+			hc.Probes = o.Probes
+		}
+	}
+	// validate has checked the durations.
+	h := Health{Failures: hc.Failures, PingNoun: strings.ToUpper(hc.PingNoun)}
+	h.Interval, _ = time.ParseDuration(hc.Interval)
+	h.Timeout, _ = time.ParseDuration(hc.Timeout)
+	if hc.Slow != "" {
+		h.Slow, _ = time.ParseDuration(hc.Slow)
+	}
+	for _, p := range hc.Probes {
+		h.Probes = append(h.Probes, strings.ToUpper(p))
+	}
+	return h
+}
+
+// DefaultPath returns $XDG_CONFIG_HOME/monoview/config.json (~/.config on Linux).
+func DefaultPath() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "monoview", "config.json"), nil
+}
+
+// Load reads path over the defaults. A missing file returns Default().
+func Load(path string) (Config, error) {
+	cfg := Default()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+	// Decoding an array into a slice reuses its elements, so a rule or widget
+	// would keep fields of the default at its index. Decode into nil slices
+	// and put the defaults back only when the key is missing.
+	def := cfg
+	cfg.Alerts, cfg.Dashboard.Widgets = nil, nil
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+	if cfg.Alerts == nil {
+		cfg.Alerts = def.Alerts
+	}
+	if cfg.Dashboard.Widgets == nil {
+		cfg.Dashboard.Widgets = def.Dashboard.Widgets
+	}
+	nodes := make(map[string]HealthCheck, len(cfg.Nodes))
+	for name, hc := range cfg.Nodes {
+		nodes[strings.ToUpper(name)] = hc
+	}
+	cfg.Nodes = nodes
+	if err := cfg.validate(); err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// SaveHubs writes hubs and the startup hub into the config file at path and
+// leaves the rest of the file, key order and formatting included, as it is.
+// The file is replaced atomically; a missing one is created.
+func SaveHubs(path string, hubs []HubProfile, active string) error {
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		data = []byte("{}\n")
+	case err != nil:
+		return err
+	}
+	c := Default()
+	c.Hubs, c.Hub = hubs, active
+	if err := c.validate(); err != nil {
+		return err
+	}
+	if data, err = setTopLevel(data, "hubs", hubs); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	var hub any
+	if active != "" {
+		hub = active
+	}
+	if data, err = setTopLevel(data, "hub", hub); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return store.WriteFile(path, data)
+}
+
+// member is one key of a JSON object: the value is data[start:end], and
+// from is where the previous member (or the opening brace) ended.
+type member struct {
+	key              string
+	from, start, end int
+}
+
+// setTopLevel sets key of the top-level object in data to value, or removes
+// it for a nil value, without touching the bytes of the other members.
+func setTopLevel(data []byte, key string, value any) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return nil, errors.New("not a JSON object")
+	}
+	var members []member
+	for dec.More() {
+		from := int(dec.InputOffset())
+		t, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, err
+		}
+		end := int(dec.InputOffset())
+		members = append(members, member{key: t.(string), from: from, start: end - len(raw), end: end})
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	closing := int(dec.InputOffset()) - 1
+
+	idx := slices.IndexFunc(members, func(m member) bool { return m.key == key })
+	splice := func(from, to int, s string) []byte {
+		return slices.Concat(data[:from], []byte(s), data[to:])
+	}
+	if value == nil {
+		switch {
+		case idx < 0:
+			return data, nil
+		case idx == 0 && len(members) > 1:
+			// Drop the member and the comma after it.
+			return splice(keyStart(data, members[0].from), keyStart(data, members[1].from), ""), nil
+		default:
+			return splice(members[idx].from, members[idx].end, ""), nil
+		}
+	}
+	out, err := json.MarshalIndent(value, "  ", "  ")
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case idx >= 0:
+		return splice(members[idx].start, members[idx].end, string(out)), nil
+	case len(members) == 0:
+		return splice(closing, closing, fmt.Sprintf("\n  %q: %s\n", key, out)), nil
+	default:
+		last := members[len(members)-1].end
+		return splice(last, last, fmt.Sprintf(",\n  %q: %s", key, out)), nil
+	}
+}
+
+// keyStart skips the comma and white space before a member's key.
+func keyStart(data []byte, from int) int {
+	for from < len(data) && strings.ContainsRune(", \t\r\n", rune(data[from])) {
+		from++
+	}
+	return from
+}
+
+func (c Config) validate() error {
+	for i, p := range c.TimerPresets {
+		if p.Name == "" {
+			return fmt.Errorf("timer_presets[%d]: name is required", i)
+		}
+		if d, err := time.ParseDuration(p.Duration); err != nil || d <= 0 {
+			return fmt.Errorf("timer_presets[%d] (%s): invalid duration %q", i, p.Name, p.Duration)
+		}
+	}
+	if c.RecentTimers < 0 {
+		return fmt.Errorf("recent_timers must not be negative")
+	}
+	for kind := range c.Notify {
+		if !slices.Contains(notify.Kinds, kind) {
+			return fmt.Errorf("notify: unknown event %q (want %s)", kind, strings.Join(notify.Kinds, ", "))
+		}
+	}
+	if err := c.Health.validate(true); err != nil {
+		return fmt.Errorf("health: %w", err)
+	}
+	for name, hc := range c.Nodes {
+		if err := hc.validate(false); err != nil {
+			return fmt.Errorf("nodes.%s: %w", name, err)
+		}
+	}
+	for i, r := range c.Alerts {
+		if err := r.validate(); err != nil {
+			return fmt.Errorf("alerts[%d] (%s): %w", i, r.Label(), err)
+		}
+	}
+	for i, w := range c.Writable {
+		if _, err := props.ParsePath(w); err != nil {
+			return fmt.Errorf("writable[%d]: %w", i, err)
+		}
+	}
+	seen := map[string]bool{}
+	for i, h := range c.Hubs {
+		name := strings.ToLower(h.Name)
+		switch {
+		case name == "":
+			return fmt.Errorf("hubs[%d]: name is required", i)
+		case strings.ContainsAny(name, `/\ `):
+			return fmt.Errorf("hubs[%d]: name %q must not contain spaces or slashes", i, h.Name)
+		case name == "." || name == "..":
+			// The name is a state directory (hubs/<name>), and these resolve elsewhere.
+			return fmt.Errorf("hubs[%d]: name %q is reserved", i, h.Name)
+		case seen[name]:
+			return fmt.Errorf("hubs[%d]: duplicate name %q", i, h.Name)
+		case h.URL == "":
+			return fmt.Errorf("hubs[%d] (%s): url is required", i, h.Name)
+		case (h.TLSCert == "") != (h.TLSKey == ""):
+			return fmt.Errorf("hubs[%d] (%s): tls_cert and tls_key go together", i, h.Name)
+		}
+		seen[name] = true
+	}
+	if c.Hub != "" && c.FindHub(c.Hub) < 0 {
+		return fmt.Errorf("hub: no hub named %q in hubs", c.Hub)
+	}
+	if c.Dashboard.Columns < 1 {
+		return fmt.Errorf("dashboard: columns must be at least 1")
+	}
+	for i, w := range c.Dashboard.Widgets {
+		if err := w.validate(); err != nil {
+			return fmt.Errorf("dashboard.widgets[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (w Widget) validate() error {
+	if !slices.Contains(WidgetTypes, w.Type) {
+		return fmt.Errorf("unknown type %q (want %s)", w.Type, strings.Join(WidgetTypes, ", "))
+	}
+	if w.Type == "property" {
+		if _, err := props.ParsePath(w.Property); err != nil {
+			return err
+		}
+	}
+	if w.Span < 0 {
+		return fmt.Errorf("span must not be negative")
+	}
+	if w.Rows < 0 {
+		return fmt.Errorf("rows must not be negative")
+	}
+	return nil
+}
+
+// Holds reports whether a value rule's condition is met by value. Above and
+// Below compare numerically; a value that is not a number never matches them.
+func (r AlertRule) Holds(value string) bool {
+	if r.Equals != "" {
+		return strings.EqualFold(value, r.Equals)
+	}
+	v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return false
+	}
+	if r.Above != "" {
+		if limit, err := strconv.ParseFloat(r.Above, 64); err == nil && v > limit {
+			return true
+		}
+	}
+	if r.Below != "" {
+		if limit, err := strconv.ParseFloat(r.Below, 64); err == nil && v < limit {
+			return true
+		}
+	}
+	return false
+}
+
+func (r AlertRule) validate() error {
+	switch r.When {
+	case AlertOffline, AlertReboot:
+	case AlertRTT:
+		if r.Above == "" {
+			return fmt.Errorf("rtt needs above")
+		}
+	case AlertValue:
+		if _, err := props.ParsePath(r.Property); err != nil {
+			return err
+		}
+		if r.Equals == "" && r.Above == "" && r.Below == "" {
+			return fmt.Errorf("value needs equals, above or below")
+		}
+		for _, n := range []struct{ name, value string }{{"above", r.Above}, {"below", r.Below}} {
+			if _, err := strconv.ParseFloat(n.value, 64); n.value != "" && err != nil {
+				return fmt.Errorf("%s: %q is not a number", n.name, n.value)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("when: unknown condition %q (want offline, rtt, reboot or value)", r.When)
+	}
+	for _, d := range []struct{ name, value string }{{"for", r.For}, {"above", r.Above}} {
+		if d.value == "" {
+			continue
+		}
+		if v, err := time.ParseDuration(d.value); err != nil || v < 0 {
+			return fmt.Errorf("%s: invalid duration %q", d.name, d.value)
+		}
+	}
+	return nil
+}
+
+// validate checks the durations; full requires every field (the default check).
+func (h HealthCheck) validate(full bool) error {
+	for _, d := range []struct{ name, value string }{{"interval", h.Interval}, {"timeout", h.Timeout}} {
+		if d.value == "" && !full {
+			continue
+		}
+		if v, err := time.ParseDuration(d.value); err != nil || v <= 0 {
+			return fmt.Errorf("%s: invalid duration %q", d.name, d.value)
+		}
+	}
+	if h.Slow != "" {
+		if v, err := time.ParseDuration(h.Slow); err != nil || v <= 0 {
+			return fmt.Errorf("slow: invalid duration %q", h.Slow)
+		}
+	}
+	if h.Failures < 0 || (full && h.Failures == 0) {
+		return fmt.Errorf("failures must be at least 1")
+	}
+	return nil
+}
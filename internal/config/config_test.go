@@ -0,0 +1,77 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetTopLevel(t *testing.T) {
+	three := "{\n  \"a\": 1,\n  \"b\": [2, 3],\n  \"c\": \"x\"\n}\n"
+	tests := []struct {
+		name  string
+		data  string
+		key   string
+		value any
+		want  string
+	}{
+		{"add to empty", "{}", "a", 1, "{\n  \"a\": 1\n}"},
+		{"add after last", three, "d", true,
+			"{\n  \"a\": 1,\n  \"b\": [2, 3],\n  \"c\": \"x\",\n  \"d\": true\n}\n"},
+		{"replace first", three, "a", []string{"y"},
+			"{\n  \"a\": [\n    \"y\"\n  ],\n  \"b\": [2, 3],\n  \"c\": \"x\"\n}\n"},
+		{"replace middle", three, "b", 4, "{\n  \"a\": 1,\n  \"b\": 4,\n  \"c\": \"x\"\n}\n"},
+		{"replace last", three, "c", "z", "{\n  \"a\": 1,\n  \"b\": [2, 3],\n  \"c\": \"z\"\n}\n"},
+		{"remove first", three, "a", nil, "{\n  \"b\": [2, 3],\n  \"c\": \"x\"\n}\n"},
+		{"remove middle", three, "b", nil, "{\n  \"a\": 1,\n  \"c\": \"x\"\n}\n"},
+		{"remove last", three, "c", nil, "{\n  \"a\": 1,\n  \"b\": [2, 3]\n}\n"},
+		{"remove only", "{\"a\": 1}", "a", nil, "{}"},
+		{"remove missing", three, "d", nil, three},
+		{"remove from empty", "{}", "a", nil, "{}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := setTopLevel([]byte(tt.data), tt.key, tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetTopLevelNotObject(t *testing.T) {
+	for _, data := range []string{"", "[]", "null", "{\"a\": }"} {
+		if _, err := setTopLevel([]byte(data), "a", 1); err == nil {
+			t.Errorf("%q: no error", data)
+		}
+	}
+}
+
+func TestValidateHubName(t *testing.T) {
+	tests := []struct {
+		name string
+		err  string // substring of the error, "" for none
+	}{
+		{"home", ""},
+		{"", "name is required"},
+		{"a/b", "must not contain"},
+		{"my hub", "must not contain"},
+		{".", "reserved"},
+		{"..", "reserved"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Default()
+			c.Hubs = []HubProfile{{Name: tt.name, URL: "ws://localhost:8080"}}
+			err := c.validate()
+			switch {
+			case tt.err == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+				t.Fatalf("err = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+// Package creds loads and checks the client certificate, private key and CA
+// of a hub profile for mTLS. Encrypted keys may be PKCS#8 (ENCRYPTED PRIVATE
+// KEY, what `openssl genpkey -aes256` and OpenSSL 3's `openssl rsa -aes256`
+// write) or legacy PEM (Proc-Type: 4,ENCRYPTED, from `-traditional`); the
+// passphrase is asked for through a callback.
+package creds
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"monoview/internal/config"
+)
+
+// Info describes a client certificate.
+type Info struct {
+	Subject   string
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// DaysLeft returns the whole days until the certificate expires; negative once it has.
+func (i Info) DaysLeft(now time.Time) int {
+	d := i.NotAfter.Sub(now)
+	if d < 0 {
+		return -int((-d).Hours()/24) - 1
+	}
+	return int(d.Hours() / 24)
+}
+
+// Passphrase returns the passphrase of the encrypted key file keyFile.
+type Passphrase func(keyFile string) ([]byte, error)
+
+// ErrPassphrase is returned (wrapped) when the passphrase does not decrypt the key.
+var ErrPassphrase = errors.New("wrong passphrase")
+
+// CertInfo reads the first certificate of a PEM file.
+func CertInfo(certFile string) (Info, error) {
+	data, err := os.ReadFile(certFile)
+	if err != nil {
+		return Info{}, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return Info{}, fmt.Errorf("%s: no PEM certificate", certFile)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return Info{}, fmt.Errorf("%s: %w", certFile, err)
+	}
+	return Info{Subject: cert.Subject.String(), NotBefore: cert.NotBefore, NotAfter: cert.NotAfter}, nil
+}
+
+// Load checks the profile's certificate, key and CA and builds the client
+// TLS config. The certificate must parse and match the key and the CA file
+// must hold at least one certificate; an expired certificate is not an error
+// here, the caller decides how loudly to warn (see Info.DaysLeft).
+func Load(p config.HubProfile, pass Passphrase) (*tls.Config, Info, error) {
+	certPEM, err := os.ReadFile(p.TLSCert)
+	if err != nil {
+		return nil, Info{}, fmt.Errorf("client certificate: %w", err)
+	}
+	keyPEM, err := readKey(p.TLSKey, pass)
+	if err != nil {
+		return nil, Info{}, err
+	}
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, Info{}, fmt.Errorf("%s, %s: %w", p.TLSCert, p.TLSKey, err)
+	}
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, Info{}, fmt.Errorf("%s: %w", p.TLSCert, err)
+	}
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{pair},
+		ServerName:   p.ServerName,
+		MinVersion:   tls.VersionTLS12,
+	}
+	if p.TLSCA != "" {
+		caPEM, err := os.ReadFile(p.TLSCA)
+		if err != nil {
+			return nil, Info{}, fmt.Errorf("CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, Info{}, fmt.Errorf("%s: no PEM certificates", p.TLSCA)
+		}
+		cfg.RootCAs = pool
+	}
+	return cfg, Info{Subject: leaf.Subject.String(), NotBefore: leaf.NotBefore, NotAfter: leaf.NotAfter}, nil
+}
+
+// readKey returns the key file as PEM, decrypted if it is encrypted.
+func readKey(keyFile string, pass Passphrase) ([]byte, error) {
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("private key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM private key", keyFile)
+	}
+	pkcs8 := block.Type == "ENCRYPTED PRIVATE KEY"
+	//lint:ignore SA1019 legacy PEM encryption is still written by openssl -traditional
+	if !pkcs8 && !x509.IsEncryptedPEMBlock(block) {
+		return data, nil
+	}
+	if pass == nil {
+		return nil, fmt.Errorf("%s is encrypted and no passphrase is available", keyFile)
+	}
+	secret, err := pass(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	if pkcs8 {
+		der, err := decryptPKCS8(block.Bytes, secret)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", keyFile, err)
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+	}
+	//lint:ignore SA1019 see above
+	der, err := x509.DecryptPEMBlock(block, secret)
+	// The padding check misses most wrong passphrases; the garbage they
+	// decrypt to does not parse as a key.
+	if errors.Is(err, x509.IncorrectPasswordError) || (err == nil && !parsesAsKey(der)) {
+		return nil, fmt.Errorf("%s: %w", keyFile, ErrPassphrase)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", keyFile, err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: block.Type, Bytes: der}), nil
+}
+
+// parsesAsKey reports whether der is a PKCS#1, SEC 1 or PKCS#8 private key.
+func parsesAsKey(der []byte) bool {
+	if _, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return true
+	}
+	if _, err := x509.ParseECPrivateKey(der); err == nil {
+		return true
+	}
+	_, err := x509.ParsePKCS8PrivateKey(der)
+	return err == nil
+}
@@ -0,0 +1,287 @@
+// Package diag walks through the steps of reaching a hub — name lookup, TCP
+// connect, TLS handshake, WebSocket upgrade — and reports how far it got.
+// It is a separate probe connection; the hub client is not touched.
+package diag
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Step names, in the order they run.
+const (
+	StepDNS       = "DNS"
+	StepTCP       = "TCP"
+	StepTLS       = "TLS"
+	StepWebSocket = "WebSocket"
+)
+
+// Step is the outcome of one stage.
+type Step struct {
+	Name    string
+	OK      bool
+	Skipped bool   // not run because an earlier step failed (or no TLS for ws://)
+	Detail  string // what was found, or the error
+	Took    time.Duration
+}
+
+// Cert summarizes one certificate of the server's chain.
+type Cert struct {
+	Subject  string
+	Issuer   string
+	NotAfter time.Time
+	Names    []string // DNS and IP SANs
+}
+
+// Report is the result of a probe.
+type Report struct {
+	URL        string
+	At         time.Time
+	Addrs      []string // resolved addresses of the host
+	Steps      []Step
+	ServerName string // name the server certificate is checked against
+	Chain      []Cert // server certificates, leaf first
+	// NameMismatch is set when the leaf certificate is not valid for ServerName.
+	NameMismatch string
+	// ClientCert tells whether the server asked for a client certificate and took it.
+	ClientCert string
+}
+
+// OK reports whether every step that ran succeeded.
+func (r Report) OK() bool {
+	for _, s := range r.Steps {
+		if !s.OK && !s.Skipped {
+			return false
+		}
+	}
+	return len(r.Steps) > 0
+}
+
+// Probe connects to rawURL the way the hub client does and reports each step.
+// cfg is the client TLS config (nil for none); serverName overrides the host
+// for SNI and certificate checks when set.
+func Probe(ctx context.Context, rawURL string, cfg *tls.Config, serverName string) Report {
+	r := Report{URL: rawURL, At: time.Now()}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		if err == nil {
+			err = errors.New("no host")
+		}
+		r.Steps = append(r.Steps, Step{Name: StepDNS, Detail: "bad URL: " + err.Error()})
+		r.skip(StepTCP, StepTLS, StepWebSocket)
+		return r
+	}
+	secure := u.Scheme == "wss" || u.Scheme == "https"
+	host, port := u.Hostname(), u.Port()
+	if port == "" {
+		port = "80"
+		if secure {
+			port = "443"
+		}
+	}
+
+	// DNS
+	start := time.Now()
+	if ip := net.ParseIP(host); ip != nil {
+		r.Addrs = []string{ip.String()}
+		r.Steps = append(r.Steps, Step{Name: StepDNS, OK: true, Detail: "literal address", Took: time.Since(start)})
+	} else {
+		addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+		if err != nil {
+			r.Steps = append(r.Steps, Step{Name: StepDNS, Detail: err.Error(), Took: time.Since(start)})
+			r.skip(StepTCP, StepTLS, StepWebSocket)
+			return r
+		}
+		r.Addrs = addrs
+		r.Steps = append(r.Steps, Step{Name: StepDNS, OK: true, Detail: strings.Join(addrs, ", "), Took: time.Since(start)})
+	}
+
+	// TCP
+	start = time.Now()
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+	if err != nil {
+		r.Steps = append(r.Steps, Step{Name: StepTCP, Detail: err.Error(), Took: time.Since(start)})
+		r.skip(StepTLS, StepWebSocket)
+		return r
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	r.Steps = append(r.Steps, Step{Name: StepTCP, OK: true, Detail: "connected to " + conn.RemoteAddr().String(), Took: time.Since(start)})
+
+	// TLS
+	rw := conn
+	requested := false
+	if !secure {
+		r.Steps = append(r.Steps, Step{Name: StepTLS, Skipped: true, Detail: "plain WebSocket (ws://)"})
+	} else {
+		tc, ok := r.handshake(ctx, conn, host, cfg, serverName, &requested)
+		if !ok {
+			r.skip(StepWebSocket)
+			return r
+		}
+		rw = tc
+	}
+
+	// WebSocket
+	start = time.Now()
+	status, err := upgrade(rw, u)
+	step := Step{Name: StepWebSocket, Took: time.Since(start)}
+	switch {
+	case err != nil:
+		step.Detail = err.Error()
+		if requested && strings.Contains(err.Error(), "tls:") {
+			r.ClientCert = "rejected by the server: " + err.Error()
+		}
+	default:
+		step.OK, step.Detail = true, status
+		if requested {
+			r.ClientCert = "accepted"
+		}
+	}
+	r.Steps = append(r.Steps, step)
+	return r
+}
+
+// handshake runs the TLS handshake without letting it verify, so the chain
+// is known even when it is not trusted, and checks it by hand afterwards.
+func (r *Report) handshake(ctx context.Context, conn net.Conn, host string, cfg *tls.Config, serverName string, requested *bool) (*tls.Conn, bool) {
+	if cfg == nil {
+		cfg = &tls.Config{}
+	}
+	name := serverName
+	if name == "" {
+		name = cfg.ServerName
+	}
+	if name == "" {
+		name = host
+	}
+	r.ServerName = name
+
+	probe := cfg.Clone()
+	probe.ServerName = name
+	probe.InsecureSkipVerify = true
+	probe.VerifyPeerCertificate = nil
+	probe.VerifyConnection = nil
+	certs := cfg.Certificates
+	probe.Certificates = nil
+	probe.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		*requested = true
+		if len(certs) == 0 {
+			return &tls.Certificate{}, nil
+		}
+		return &certs[0], nil
+	}
+
+	start := time.Now()
+	tc := tls.Client(conn, probe)
+	if err := tc.HandshakeContext(ctx); err != nil {
+		// With TLS 1.2 a refused client certificate fails the handshake.
+		if *requested {
+			r.ClientCert = "rejected by the server: " + err.Error()
+		}
+		r.Steps = append(r.Steps, Step{Name: StepTLS, Detail: err.Error(), Took: time.Since(start)})
+		return nil, false
+	}
+	state := tc.ConnectionState()
+	for _, c := range state.PeerCertificates {
+		cert := Cert{Subject: c.Subject.String(), Issuer: c.Issuer.String(), NotAfter: c.NotAfter, Names: c.DNSNames}
+		for _, ip := range c.IPAddresses {
+			cert.Names = append(cert.Names, ip.String())
+		}
+		r.Chain = append(r.Chain, cert)
+	}
+	switch {
+	case !*requested:
+		r.ClientCert = "not requested by the server"
+	case len(certs) == 0:
+		r.ClientCert = "requested by the server, but none is configured"
+	default:
+		// With TLS 1.3 the server answers it after the handshake.
+		r.ClientCert = "sent, not checked yet"
+	}
+	step := Step{Name: StepTLS, Took: time.Since(start)}
+	if len(state.PeerCertificates) == 0 {
+		step.Detail = "server sent no certificate"
+		r.Steps = append(r.Steps, step)
+		return nil, false
+	}
+	leaf := state.PeerCertificates[0]
+	if err := leaf.VerifyHostname(name); err != nil {
+		r.NameMismatch = err.Error()
+	}
+	inter := x509.NewCertPool()
+	for _, c := range state.PeerCertificates[1:] {
+		inter.AddCert(c)
+	}
+	_, err := leaf.Verify(x509.VerifyOptions{Roots: cfg.RootCAs, Intermediates: inter, DNSName: name})
+	if err != nil {
+		step.Detail = "server certificate not trusted: " + err.Error()
+		if len(certs) > 0 && *requested {
+			r.ClientCert = "sent, not checked: the probe stops at an untrusted server"
+		}
+		r.Steps = append(r.Steps, step)
+		return nil, false
+	}
+	step.OK = true
+	step.Detail = fmt.Sprintf("%s, %s", tls.VersionName(state.Version), tls.CipherSuiteName(state.CipherSuite))
+	r.Steps = append(r.Steps, step)
+	return tc, true
+}
+
+// upgrade sends the WebSocket opening handshake and returns the response status.
+func upgrade(conn net.Conn, u *url.URL) (string, error) {
+	nonce := make([]byte, 16)
+	rand.Read(nonce)
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	target := *u
+	target.Scheme = "http"
+	if u.Scheme == "wss" {
+		target.Scheme = "https"
+	}
+	req, err := http.NewRequest(http.MethodGet, target.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Sec-WebSocket-Key", key)
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	if err := req.Write(conn); err != nil {
+		return "", err
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		return "", fmt.Errorf("upgrade refused: %s", resp.Status)
+	}
+	sum := sha1.Sum([]byte(key + "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"))
+	if resp.Header.Get("Sec-WebSocket-Accept") != base64.StdEncoding.EncodeToString(sum[:]) {
+		return "", errors.New("upgrade answered with a wrong Sec-WebSocket-Accept")
+	}
+	return resp.Status, nil
+}
+
+func (r *Report) skip(names ...string) {
+	for _, n := range names {
+		r.Steps = append(r.Steps, Step{Name: n, Skipped: true})
+	}
+}
@@ -0,0 +1,118 @@
+// Package logfile is an append-only log file that rotates by size: when a
+// write would take it past the limit, monoview.log becomes monoview.log.1,
+// .1 becomes .2 and so on, and the oldest beyond the kept count is removed.
+package logfile
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+)
+
+// File is a size-rotated log file. It is safe for concurrent writes.
+type File struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int64 // bytes; 0 never rotates
+	keep    int   // rotated files kept next to path
+	f       *os.File
+	size    int64
+}
+
+// Open opens path for appending. maxSize 0 turns rotation off.
+func Open(path string, maxSize int64, keep int) (*File, error) {
+	l := &File{path: path, maxSize: maxSize, keep: max(keep, 0)}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *File) open() error {
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	l.f, l.size = f, info.Size()
+	return nil
+}
+
+// Write appends p, rotating first if p would not fit. A single write larger
+// than the limit still goes into a fresh file whole. If rotating fails, p is
+// appended to the current file anyway, the error is returned, and the next
+// write tries again.
+func (l *File) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return 0, fs.ErrClosed
+	}
+	var rotateErr error
+	if l.maxSize > 0 && l.size > 0 && l.size+int64(len(p)) > l.maxSize {
+		if err := l.rotate(); err != nil {
+			rotateErr = fmt.Errorf("rotate %s: %w", l.path, err)
+			if l.f == nil {
+				return 0, rotateErr
+			}
+		}
+	}
+	n, err := l.f.Write(p)
+	l.size += int64(n)
+	if err == nil {
+		err = rotateErr
+	}
+	return n, err
+}
+
+// rotate shifts path.N-1 → path.N … path → path.1 and starts an empty path.
+// Whatever fails, path is opened again for appending so logging goes on.
+func (l *File) rotate() error {
+	err := l.f.Close()
+	l.f = nil
+	if err == nil {
+		err = l.shift()
+	}
+	if openErr := l.open(); openErr != nil {
+		return errors.Join(err, openErr)
+	}
+	return err
+}
+
+// shift renames the rotated files up by one and path to path.1.
+func (l *File) shift() error {
+	if l.keep == 0 {
+		if err := os.Remove(l.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	for i := l.keep - 1; i >= 1; i-- {
+		err := os.Rename(fmt.Sprintf("%s.%d", l.path, i), fmt.Sprintf("%s.%d", l.path, i+1))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	if err := os.Rename(l.path, l.path+".1"); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Close closes the file.
+func (l *File) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return nil
+	}
+	err := l.f.Close()
+	l.f = nil
+	return err
+}
@@ -0,0 +1,183 @@
+// Package notify delivers desktop and terminal notifications for monoview
+// events (timer/alarm fired, node offline, deadline today, alert raised). Each event kind is
+// routed to any number of notifiers: terminal bell, OSC 9 / OSC 777 escape
+// sequences, notify-send, or a user shell hook.
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// Event kinds that can be routed to notifiers.
+const (
+	KindTimer    = "timer"
+	KindAlarm    = "alarm"
+	KindOffline  = "offline"
+	KindDeadline = "deadline"
+	KindAlert    = "alert"
+)
+
+// Kinds lists every event kind, for config validation.
+var Kinds = []string{KindTimer, KindAlarm, KindOffline, KindDeadline, KindAlert}
+
+// Event is one notification.
+type Event struct {
+	Kind  string
+	Title string
+	Body  string
+}
+
+// Notifier delivers an event. Notify must not block on slow external programs.
+type Notifier interface {
+	Notify(e Event) error
+}
+
+// Terminal is the terminal shared by the UI renderer and the terminal
+// notifiers. Writes are serialized, so a bell or OSC sequence never lands in
+// the middle of a frame (the renderer writes each frame in one Write). It
+// keeps Read, Close and Fd so the UI still sees a terminal.
+type Terminal struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+// NewTerminal wraps f, normally os.Stdout.
+func NewTerminal(f *os.File) *Terminal { return &Terminal{f: f} }
+
+func (t *Terminal) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.f.Write(p)
+}
+
+func (t *Terminal) Read(p []byte) (int, error) { return t.f.Read(p) }
+func (t *Terminal) Close() error               { return t.f.Close() }
+func (t *Terminal) Fd() uintptr                { return t.f.Fd() }
+
+// Bell rings the terminal bell.
+type Bell struct{ Out io.Writer }
+
+func (n Bell) Notify(Event) error {
+	_, err := io.WriteString(n.Out, "\a")
+	return err
+}
+
+// OSC9 sends an OSC 9 desktop notification (iTerm2, kitty, WezTerm, Windows Terminal).
+type OSC9 struct{ Out io.Writer }
+
+func (n OSC9) Notify(e Event) error {
+	_, err := fmt.Fprintf(n.Out, "\x1b]9;%s\x07", oscText(e.Title+": "+e.Body))
+	return err
+}
+
+// OSC777 sends an OSC 777 notification (urxvt, foot, VTE-based terminals).
+type OSC777 struct{ Out io.Writer }
+
+func (n OSC777) Notify(e Event) error {
+	_, err := fmt.Fprintf(n.Out, "\x1b]777;notify;%s;%s\x07", oscText(e.Title), oscText(e.Body))
+	return err
+}
+
+// oscText strips characters that would end or break an OSC sequence.
+func oscText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == ';' {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+// NotifySend shows a desktop notification through notify-send (D-Bus).
+type NotifySend struct{}
+
+func (NotifySend) Notify(e Event) error {
+	return start(exec.Command("notify-send", "--app-name=monoview", e.Title, e.Body))
+}
+
+// Hook runs a shell command with the event in MONOVIEW_EVENT, MONOVIEW_TITLE
+// and MONOVIEW_BODY.
+type Hook struct{ Command string }
+
+func (n Hook) Notify(e Event) error {
+	if n.Command == "" {
+		return errors.New("hook: no command configured")
+	}
+	cmd := exec.Command("sh", "-c", n.Command)
+	cmd.Env = append(os.Environ(),
+		"MONOVIEW_EVENT="+e.Kind,
+		"MONOVIEW_TITLE="+e.Title,
+		"MONOVIEW_BODY="+e.Body,
+	)
+	return start(cmd)
+}
+
+// start launches cmd without waiting for it; the process is reaped in the background.
+func start(cmd *exec.Cmd) error {
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
+
+// New returns the notifier called name: bell, osc9, osc777, notify-send or hook.
+// Terminal notifiers write to out; hook runs hookCmd.
+func New(name string, out io.Writer, hookCmd string) (Notifier, error) {
+	switch strings.ToLower(name) {
+	case "bell":
+		return Bell{Out: out}, nil
+	case "osc9":
+		return OSC9{Out: out}, nil
+	case "osc777":
+		return OSC777{Out: out}, nil
+	case "notify-send":
+		return NotifySend{}, nil
+	case "hook":
+		if hookCmd == "" {
+			return nil, errors.New(`notifier "hook" needs notify_hook to be set`)
+		}
+		return Hook{Command: hookCmd}, nil
+	}
+	return nil, fmt.Errorf("unknown notifier %q (want bell, osc9, osc777, notify-send or hook)", name)
+}
+
+// Dispatcher routes events to the notifiers configured for their kind.
+type Dispatcher struct {
+	routes map[string][]Notifier
+}
+
+// NewDispatcher builds a dispatcher from kind -> notifier names.
+func NewDispatcher(routes map[string][]string, out io.Writer, hookCmd string) (*Dispatcher, error) {
+	d := &Dispatcher{routes: map[string][]Notifier{}}
+	for kind, names := range routes {
+		for _, name := range names {
+			n, err := New(name, out, hookCmd)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", kind, err)
+			}
+			d.routes[kind] = append(d.routes[kind], n)
+		}
+	}
+	return d, nil
+}
+
+// Notify sends e to every notifier routed for e.Kind and returns their errors joined.
+func (d *Dispatcher) Notify(e Event) error {
+	if d == nil {
+		return nil
+	}
+	var errs []error
+	for _, n := range d.routes[e.Kind] {
+		if err := n.Notify(e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
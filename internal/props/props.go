@@ -0,0 +1,138 @@
+// Package props keeps the last value of every property a node has reported
+// in an OK reply (TO:OK:<TOPIC>:<PROP>:<VALUE>:FROM), as a node → topic →
+// property tree. Values are addressed by paths like "VERTEX.LED.BRIGHT";
+// replies without a property (OK:UPTIME:123) are stored as "VERTEX.UPTIME".
+package props
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Value is the last reported value of a property.
+type Value struct {
+	Value string
+	At    time.Time
+}
+
+// Path addresses one property. Prop is "" for topic-level values.
+type Path struct {
+	Node  string
+	Topic string
+	Prop  string
+}
+
+// ParsePath parses "NODE.TOPIC[.PROP]" (case-insensitive).
+func ParsePath(s string) (Path, error) {
+	parts := strings.Split(strings.ToUpper(strings.TrimSpace(s)), ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Path{}, fmt.Errorf("property path %q: want NODE.TOPIC or NODE.TOPIC.PROP", s)
+	}
+	for _, p := range parts {
+		if p == "" {
+			return Path{}, fmt.Errorf("property path %q: empty part", s)
+		}
+	}
+	p := Path{Node: parts[0], Topic: parts[1]}
+	if len(parts) == 3 {
+		p.Prop = parts[2]
+	}
+	return p, nil
+}
+
+func (p Path) String() string {
+	if p.Prop == "" {
+		return p.Node + "." + p.Topic
+	}
+	return p.Node + "." + p.Topic + "." + p.Prop
+}
+
+// Match reports whether p matches pattern, where a pattern part of "*" matches anything.
+func (p Path) Match(pattern Path) bool {
+	match := func(part, pat string) bool { return pat == "*" || part == pat }
+	return match(p.Node, pattern.Node) && match(p.Topic, pattern.Topic) && match(p.Prop, pattern.Prop)
+}
+
+// Store is the property tree. The zero value is not usable; call New.
+type Store struct {
+	nodes map[string]map[string]map[string]Value
+}
+
+// New returns an empty store.
+func New() *Store {
+	return &Store{nodes: map[string]map[string]map[string]Value{}}
+}
+
+// Set records value for path at time at.
+func (s *Store) Set(p Path, value string, at time.Time) {
+	topics := s.nodes[p.Node]
+	if topics == nil {
+		topics = map[string]map[string]Value{}
+		s.nodes[p.Node] = topics
+	}
+	props := topics[p.Topic]
+	if props == nil {
+		props = map[string]Value{}
+		topics[p.Topic] = props
+	}
+	props[p.Prop] = Value{Value: value, At: at}
+}
+
+// SetReply records an OK reply from node: args are [value] or [prop, value...].
+// Returns false when there is nothing to record.
+func (s *Store) SetReply(node, topic string, args []string, at time.Time) (Path, bool) {
+	if len(args) == 0 {
+		return Path{}, false
+	}
+	p := Path{Node: strings.ToUpper(node), Topic: strings.ToUpper(topic)}
+	value := args[0]
+	if len(args) > 1 {
+		p.Prop = strings.ToUpper(args[0])
+		value = strings.Join(args[1:], ":")
+	}
+	s.Set(p, value, at)
+	return p, true
+}
+
+// Get returns the value at path.
+func (s *Store) Get(p Path) (Value, bool) {
+	v, ok := s.nodes[p.Node][p.Topic][p.Prop]
+	return v, ok
+}
+
+// Nodes returns the nodes with at least one value, sorted.
+func (s *Store) Nodes() []string {
+	return sortedKeys(s.nodes)
+}
+
+// Topics returns the node's topics, sorted.
+func (s *Store) Topics(node string) []string {
+	return sortedKeys(s.nodes[node])
+}
+
+// Props returns the topic's properties, sorted ("" first when the topic has a value itself).
+func (s *Store) Props(node, topic string) []string {
+	return sortedKeys(s.nodes[node][topic])
+}
+
+// Paths returns every path of node, sorted by topic then property.
+func (s *Store) Paths(node string) []Path {
+	var out []Path
+	for _, t := range s.Topics(node) {
+		for _, p := range s.Props(node, t) {
+			out = append(out, Path{Node: node, Topic: t, Prop: p})
+		}
+	}
+	return out
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
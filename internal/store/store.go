@@ -0,0 +1,81 @@
+// Package store persists small JSON documents (UI state, caches) under the
+// user's XDG directories so they survive restarts.
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+const appDir = "monoview"
+
+// StatePath returns the path of name under $XDG_STATE_HOME/monoview
+// (default ~/.local/state/monoview). Use it for data the user produced.
+func StatePath(name string) (string, error) {
+	base := os.Getenv("XDG_STATE_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		base = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(base, appDir, name), nil
+}
+
+// CachePath returns the path of name under the user cache dir
+// ($XDG_CACHE_HOME/monoview on Linux). Use it for data that can be re-fetched.
+func CachePath(name string) (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, appDir, name), nil
+}
+
+// Load decodes the JSON file at path into v. A missing file is not an error
+// and leaves v untouched.
+func Load(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// Save encodes v as JSON and writes it to path atomically, see WriteFile.
+func Save(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return WriteFile(path, data)
+}
+
+// WriteFile writes data to path atomically (temp file + rename, mode 0600),
+// creating parent directories as needed. A crash leaves the old file whole.
+func WriteFile(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".tmp-"+filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -10,6 +10,9 @@ const (
 	SheetDiary
 	SheetHome
 	SheetSystem
+	SheetDeadlines
+	SheetProps
+	SheetDashboard
 )
 
 var SheetNames = []string{
@@ -17,6 +20,9 @@ var SheetNames = []string{
 	"[2] DIARY",
 	"[3] HOME",
 	"[4] SYSTEM",
+	"[5] DEADLINES",
+	"[6] PROPS",
+	"[7] DASH",
 }
 
 // Event represents a calendar event (synced from GOVERNOR when connected).
@@ -24,7 +30,7 @@ type Event struct {
 	ID       string // Governor event id (for STOP:EVENT:<id>)
 	Date     time.Time
 	Title    string
-	Category string // work, personal, deadline, system; inferred from notes if from governor
+	Category string // work, personal, deadline, holiday, system; inferred from notes if from governor
 	Location string // optional
 	Notes    string // optional
 }
@@ -75,18 +81,84 @@ type HomeDevice struct {
 type SystemNode struct {
 	Name     string
 	PingNoun string    // noun for PING command ("PINT" for VERTEX, "PING" for ACHTUNG)
-	Status   string    // "online", "offline", "unknown"
+	Status   string    // "online", "degraded", "offline", "unknown"
 	Uptime   string    // human-readable uptime from GET:UPTIME
 	LastSeen time.Time // last time we got a PONG
 	PingMs   int64     // last round-trip in ms
-	PingSent time.Time // when we last sent PING (for RTT calc)
+	PingSent time.Time // when we last sent PING (next probe is due an interval later)
+	Since    time.Time // when Status last changed, or when the node was added
+
+	Pings    []time.Time       // unanswered PINGs, oldest first; a PONG answers the oldest
+	Failures int               // probes in a row that timed out
+	Probes   map[string]string // last reply to each GET probe, by noun
+
+	Discovered bool // learned from concentrator traffic, not in the built-in list
+	Pinned     bool // discovered node kept across restarts
+}
+
+// KnownNode is what monoview remembers about a discovered node.
+type KnownNode struct {
+	PingNoun  string // last noun tried, or the one a PONG confirmed
+	Learned   bool   // PingNoun was confirmed by a PONG
+	Misses    int    // probes without a PONG since the noun last changed
+	Pinned    bool   // shown (and pinged) from startup
+	Hidden    bool   // ignored until unhidden
+	FirstSeen time.Time
+}
+
+// NodeMessage is one message to or from a node, as shown in the node view.
+type NodeMessage struct {
+	At   time.Time
+	Out  bool   // sent by monoview
+	Text string // TO:VERB:NOUN[:ARGS] or the raw received line
+}
+
+// ProtocolError is a payload from the hub that was rejected.
+type ProtocolError struct {
+	At     time.Time
+	Node   string // sender
+	Raw    string // the whole frame
+	Reason string // why it (or one item of it) was rejected
+	Whole  bool   // the whole frame was dropped, not just one item
+}
+
+// NodeSample is one health probe of a node: the PING round-trip, or a miss.
+type NodeSample struct {
+	At    time.Time
+	RTTMs int64 // -1 = no PONG before the next probe
+}
+
+// NodeEvent is a change in a node's health: "online", "degraded", "offline" or "reboot" (uptime went backwards).
+type NodeEvent struct {
+	At   time.Time
+	Kind string
+}
+
+// NodeHistory is the rolling health record of one node (kept for a week).
+type NodeHistory struct {
+	Samples    []NodeSample
+	Events     []NodeEvent
+	LastUptime time.Duration // last GET:UPTIME, to detect reboots
 }
 
 // FireAlert is shown when ACHTUNG broadcasts ALL:FIRE:TIMER/ALARM:name.
 type FireAlert struct {
-	Show    bool
 	JobKind string // "TIMER" or "ALARM"
 	JobName string
+	FiredAt time.Time
+	Outcome string // "" while pending, then "dismissed", "snoozed" or "missed" (never acknowledged)
+}
+
+// NodeAlert is raised by a config alert rule on a node's state.
+type NodeAlert struct {
+	Rule     int    // index into the config's alert rules
+	Label    string // rule label
+	Hub      string // hub the node is on ("" with a single hub)
+	Node     string
+	Detail   string // what happened, e.g. "offline since 14:02"
+	Raised   time.Time
+	Resolved time.Time // zero while the condition holds
+	Acked    bool
 }
 
 // AchtungJob is a timer or alarm on the ACHTUNG node.
@@ -94,9 +166,64 @@ type FireAlert struct {
 type AchtungJob struct {
 	Kind      string // "TIMER" or "ALARM"
 	Name      string
-	Remaining string     // human-readable countdown, updated from EndTime
-	Due       string     // human-readable due date/time from server
-	EndTime   *time.Time // when set, Remaining is computed each tick until this time
+	Remaining string        // human-readable countdown, updated from EndTime
+	Due       string        // human-readable due date/time from server
+	EndTime   *time.Time    // when set, Remaining is computed each tick until this time
+	Total     time.Duration // full timer length for the progress bar; 0 = unknown
+
+	// Paused timers are stopped on ACHTUNG and kept locally until resumed.
+	Paused     bool
+	PausedLeft time.Duration
+}
+
+// RecurringAlarm is a repeating alarm kept by monoview; each occurrence is
+// armed on ACHTUNG as a one-shot ALARM with the same name.
+type RecurringAlarm struct {
+	Name         string
+	Time         string         // "HH:MM"
+	Days         []time.Weekday // days it fires on
+	SkipHolidays bool           // skip days with a GOVERNOR event marked holiday
+	NextAt       time.Time      // occurrence currently armed on ACHTUNG
+	ArmedAt      time.Time      // when NextAt was sent
+}
+
+// PomodoroPlan is the work/break cycle for pomodoro mode.
+type PomodoroPlan struct {
+	Work       time.Duration
+	ShortBreak time.Duration
+	LongBreak  time.Duration
+	LongEvery  int // long break after every N work sessions
+	DimTo      int // VERTEX LED brightness during breaks; -1 = leave as is
+}
+
+// Kiosk is the read-only display mode (--kiosk).
+type Kiosk struct {
+	Enabled   bool
+	Rotate    time.Duration // time on each sheet; 0 = no rotation
+	Sheets    []Sheet       // rotation order
+	NightFrom time.Duration // night starts this long after midnight
+	NightTo   time.Duration // and ends this long after midnight; equal = never
+}
+
+// Pomodoro is the running pomodoro session; the current phase is an ACHTUNG timer named JobName.
+type Pomodoro struct {
+	Active  bool
+	Phase   string    // "work", "short" or "long"
+	Cycle   int       // 1-based work session number within this run
+	Started time.Time // start of the run, part of every phase's job name
+	JobName string
+}
+
+// OutboxEntry is a user-initiated command queued while the concentrator is unreachable.
+type OutboxEntry struct {
+	To     string
+	Verb   string
+	Noun   string
+	Args   []string
+	Queued time.Time
+	// Recreate marks a NEW re-creating a job shown here (resume, extend,
+	// snooze): it is sent on replay even though the job exists locally.
+	Recreate bool
 }
 
 // LogEntry represents a log line
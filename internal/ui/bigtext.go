@@ -0,0 +1,47 @@
+package ui
+
+import "strings"
+
+// bigGlyphs is a 3x5 block font for digits and ':' (used by full-screen countdowns).
+var bigGlyphs = map[rune][5]string{
+	'0': {"███", "█ █", "█ █", "█ █", "███"},
+	'1': {" █ ", "██ ", " █ ", " █ ", "███"},
+	'2': {"███", "  █", "███", "█  ", "███"},
+	'3': {"███", "  █", "███", "  █", "███"},
+	'4': {"█ █", "█ █", "███", "  █", "  █"},
+	'5': {"███", "█  ", "███", "  █", "███"},
+	'6': {"███", "█  ", "███", "█ █", "███"},
+	'7': {"███", "  █", "  █", "  █", "  █"},
+	'8': {"███", "█ █", "███", "█ █", "███"},
+	'9': {"███", "█ █", "███", "  █", "███"},
+	':': {" ", "█", " ", "█", " "},
+	'-': {"   ", "   ", "███", "   ", "   "},
+	' ': {" ", " ", " ", " ", " "},
+}
+
+// BigText renders s (digits, ':', '-', ' ') five rows tall, each cell doubled
+// horizontally so the digits look square in a terminal. Other runes are skipped.
+func BigText(s string) string {
+	var rows [5]strings.Builder
+	first := true
+	for _, r := range s {
+		g, ok := bigGlyphs[r]
+		if !ok {
+			continue
+		}
+		for i := range rows {
+			if !first {
+				rows[i].WriteString("  ")
+			}
+			for _, c := range g[i] {
+				rows[i].WriteString(strings.Repeat(string(c), 2))
+			}
+		}
+		first = false
+	}
+	out := make([]string, len(rows))
+	for i := range rows {
+		out[i] = rows[i].String()
+	}
+	return strings.Join(out, "\n")
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // RenderBar renders a progress bar
@@ -48,3 +49,54 @@ func IndentLines(s string, prefix string) string {
 	}
 	return strings.Join(lines, "\n")
 }
+
+// sparkRunes are the eight block heights used by Sparkline.
+var sparkRunes = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as block characters scaled between their min and
+// max, right-aligned in width cells. Negative values (missing data) render as
+// a red "·".
+func Sparkline(values []float64, width int) string {
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+	lo, hi := -1.0, -1.0
+	for _, v := range values {
+		if v < 0 {
+			continue
+		}
+		if lo < 0 || v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+	var b strings.Builder
+	b.WriteString(strings.Repeat(" ", width-len(values)))
+	miss := lipgloss.NewStyle().Foreground(GruvRed)
+	bar := lipgloss.NewStyle().Foreground(GruvAqua)
+	for _, v := range values {
+		if v < 0 {
+			b.WriteString(miss.Render("·"))
+			continue
+		}
+		i := 0
+		if hi > lo {
+			i = int((v - lo) / (hi - lo) * float64(len(sparkRunes)-1))
+		}
+		b.WriteString(bar.Render(string(sparkRunes[i])))
+	}
+	return b.String()
+}
+
+// Dimmed drops the colors of s and draws every line in dark gray, for a
+// screen that should not light up a dark room.
+func Dimmed(s string) string {
+	style := lipgloss.NewStyle().Foreground(GruvBg2)
+	lines := strings.Split(ansi.Strip(s), "\n")
+	for i, l := range lines {
+		lines[i] = style.Render(l)
+	}
+	return strings.Join(lines, "\n")
+}